@@ -4,9 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
 )
 
 type Config struct {
@@ -39,3 +45,117 @@ func (c *Config) Client(ctx context.Context) (*cloudflare.API, error) {
 	tflog.Info(ctx, fmt.Sprintf("cloudflare Client configured for user: %s", c.Email))
 	return client, nil
 }
+
+// retryTransport is a http.RoundTripper that owns rate limiting and retries
+// for the Cloudflare client. Every attempt, retries included, waits on the
+// shared token bucket, and rate limited responses are retried once their
+// `Retry-After` delay has elapsed. cloudflare-go retries 429 responses with
+// its own backoff which ignores the header, so the client must be configured
+// without retries and without a rate limit of its own when this transport is
+// used, otherwise the retries nest.
+type retryTransport struct {
+	next       http.RoundTripper
+	limiter    *rate.Limiter
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryTransport(next http.RoundTripper, limiter *rate.Limiter, maxRetries int, minBackoff, maxBackoff time.Duration) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &retryTransport{next: next, limiter: limiter, maxRetries: maxRetries, minBackoff: minBackoff, maxBackoff: maxBackoff}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, fmt.Errorf("error caused by request rate limiting: %w", err)
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.maxRetries || !retryableResponse(resp, err) {
+			return resp, err
+		}
+
+		// Requests with a body can only be replayed if it can be recreated.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := retryAfterDuration(resp); ok {
+				wait = retryAfter
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if t.maxBackoff > 0 && wait > t.maxBackoff {
+			wait = t.maxBackoff
+		}
+
+		tflog.Debug(req.Context(), fmt.Sprintf("retrying %s %s in %s, attempt %d of %d", req.Method, req.URL.Path, wait, attempt+1, t.maxRetries))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, fmt.Errorf("operation aborted during backoff: %w", req.Context().Err())
+		case <-timer.C:
+		}
+
+		req = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// backoff doubles the minimum backoff on every attempt, the same way
+// cloudflare-go does.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	return time.Duration(math.Pow(2, float64(attempt))) * t.minBackoff
+}
+
+// retryableResponse reports whether a request should be retried, matching the
+// responses cloudflare-go retries: rate limited and server errors as well as
+// failed requests other than timeouts.
+func retryableResponse(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled)
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// retryAfterDuration returns how long to wait before retrying a rate limited
+// response. `Retry-After` may be either a number of seconds or a HTTP date.
+func retryAfterDuration(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,123 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cloudflare "github.com/cloudflare/cloudflare-go"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+// newRetryTransportTestClient configures the client the same way the provider
+// does, leaving retries and rate limiting to the transport.
+func newRetryTransportTestClient(t *testing.T, limiter *rate.Limiter, maxRetries int, handler http.HandlerFunc) *cloudflare.API {
+	return newMockCloudflareClient(t, handler,
+		cloudflare.UsingRateLimit(float64(rate.Inf)),
+		cloudflare.HTTPClient(&http.Client{
+			Transport: newRetryTransport(http.DefaultTransport, limiter, maxRetries, 10*time.Millisecond, 5*time.Second),
+		}),
+	)
+}
+
+func TestRetryTransportWaitsForRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	client := newRetryTransportTestClient(t, rate.NewLimiter(rate.Inf, 1), 3, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "Rate limited"}], "messages": [], "result": null}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "app-id", "name": "example"}}`)
+	})
+
+	start := time.Now()
+	app, err := client.GetAccessApplication(context.Background(), cloudflare.AccountIdentifier("account-id"), "app-id")
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "app-id", app.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.GreaterOrEqual(t, elapsed, time.Second)
+}
+
+func TestRetryTransportDoesNotNestRetries(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	client := newRetryTransportTestClient(t, rate.NewLimiter(rate.Inf, 1), 2, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "Rate limited"}], "messages": [], "result": null}`)
+	})
+
+	_, err := client.GetAccessApplication(context.Background(), cloudflare.AccountIdentifier("account-id"), "app-id")
+	assert.Error(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestRetryTransportRateLimitsRetries(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	// Two requests per second, so the two retries wait at least a second on
+	// the token bucket despite the short backoff.
+	client := newRetryTransportTestClient(t, rate.NewLimiter(2, 1), 3, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "app-id", "name": "example"}}`)
+	})
+
+	start := time.Now()
+	_, err := client.GetAccessApplication(context.Background(), cloudflare.AccountIdentifier("account-id"), "app-id")
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.GreaterOrEqual(t, elapsed, 900*time.Millisecond)
+}
+
+func TestRetryAfterDuration(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		status     int
+		retryAfter string
+		expected   time.Duration
+		ok         bool
+	}{
+		{http.StatusTooManyRequests, "2", 2 * time.Second, true},
+		{http.StatusTooManyRequests, "0", 0, true},
+		{http.StatusTooManyRequests, "", 0, false},
+		{http.StatusTooManyRequests, "soon", 0, false},
+		{http.StatusTooManyRequests, "Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+		{http.StatusServiceUnavailable, "2", 0, false},
+	}
+
+	for _, c := range cases {
+		resp := &http.Response{StatusCode: c.status, Header: http.Header{}}
+		if c.retryAfter != "" {
+			resp.Header.Set("Retry-After", c.retryAfter)
+		}
+
+		got, ok := retryAfterDuration(resp)
+		assert.Equal(t, c.ok, ok, "Retry-After %q", c.retryAfter)
+		assert.Equal(t, c.expected, got, "Retry-After %q", c.retryAfter)
+	}
+}
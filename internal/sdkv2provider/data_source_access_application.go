@@ -30,19 +30,21 @@ func dataSourceCloudflareAccessApplication() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"name", "domain"},
+				ExactlyOneOf: []string{"name", "domain", "aud"},
 			},
 			"domain": {
 				Description:  "The primary hostname and path that Access will secure.",
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"name", "domain"},
+				ExactlyOneOf: []string{"name", "domain", "aud"},
 			},
 			"aud": {
-				Description: "Application Audience (AUD) Tag of the application.",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Description:  "Application Audience (AUD) Tag of the application.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "domain", "aud"},
 			},
 		},
 		Description:        "Use this data source to lookup a single [Access Application](https://developers.cloudflare.com/cloudflare-one/applications/)",
@@ -71,19 +73,21 @@ func dataSourceCloudflareZeroTrustAccessApplication() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"name", "domain"},
+				ExactlyOneOf: []string{"name", "domain", "aud"},
 			},
 			"domain": {
 				Description:  "The primary hostname and path that Access will secure.",
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"name", "domain"},
+				ExactlyOneOf: []string{"name", "domain", "aud"},
 			},
 			"aud": {
-				Description: "Application Audience (AUD) Tag of the application.",
-				Type:        schema.TypeString,
-				Computed:    true,
+				Description:  "Application Audience (AUD) Tag of the application.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "domain", "aud"},
 			},
 		},
 		Description: "Use this data source to lookup a single [Access Application](https://developers.cloudflare.com/cloudflare-one/applications/)",
@@ -99,23 +103,19 @@ func dataSourceCloudflareAccessApplicationRead(ctx context.Context, d *schema.Re
 	}
 	name := d.Get("name").(string)
 	domain := d.Get("domain").(string)
+	aud := d.Get("aud").(string)
 
-	applications, _, err := client.ListAccessApplications(ctx, identifier, cloudflare.ListAccessApplicationsParams{})
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error listing Access Applications: %w", err))
-	}
-	if len(applications) == 0 {
-		return diag.Errorf("no Access Applications found")
-	}
-	var accessApplication cloudflare.AccessApplication
-	for _, application := range applications {
-		if application.Name == name || application.Domain == domain {
-			accessApplication = application
-			break
+	accessApplication, err := findAccessApplication(ctx, client, identifier, func(application cloudflare.AccessApplication) bool {
+		if aud != "" {
+			return application.AUD == aud
 		}
+		return application.Name == name || application.Domain == domain
+	})
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	if accessApplication.ID == "" {
-		return diag.Errorf("no Access Application matching name %q domain %q", name, domain)
+		return diag.Errorf("no Access Application matching name %q domain %q aud %q", name, domain, aud)
 	}
 	d.SetId(accessApplication.ID)
 	d.Set("name", accessApplication.Name)
@@ -123,3 +123,36 @@ func dataSourceCloudflareAccessApplicationRead(ctx context.Context, d *schema.Re
 	d.Set("aud", accessApplication.AUD)
 	return nil
 }
+
+// findAccessApplication walks the Access Applications one page at a time and
+// returns the first one accepted by match, so a lookup stops fetching as soon
+// as the application is found. The API offers no filter by name, domain or
+// AUD tag.
+func findAccessApplication(ctx context.Context, client *cloudflare.API, identifier *cloudflare.ResourceContainer, match func(cloudflare.AccessApplication) bool) (cloudflare.AccessApplication, error) {
+	params := cloudflare.ListAccessApplicationsParams{
+		ResultInfo: cloudflare.ResultInfo{Page: 1, PerPage: 50},
+	}
+	found := false
+	for {
+		applications, resultInfo, err := client.ListAccessApplications(ctx, identifier, params)
+		if err != nil {
+			return cloudflare.AccessApplication{}, fmt.Errorf("error listing Access Applications: %w", err)
+		}
+		if len(applications) > 0 {
+			found = true
+		}
+		for _, application := range applications {
+			if match(application) {
+				return application, nil
+			}
+		}
+		if resultInfo == nil || !resultInfo.HasMorePages() {
+			break
+		}
+		params.ResultInfo.Page = resultInfo.Page + 1
+	}
+	if !found {
+		return cloudflare.AccessApplication{}, fmt.Errorf("no Access Applications found")
+	}
+	return cloudflare.AccessApplication{}, nil
+}
@@ -1,10 +1,14 @@
 package sdkv2provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/cloudflare/terraform-provider-cloudflare/internal/consts"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -156,3 +160,85 @@ func testAccCheckCloudflareAccessApplicationZoneDomain(zoneID, name, domain stri
 	}
 	`, name, zoneID, domain)
 }
+
+func TestAccCloudflareAccessApplicationDataSource_AccountAUD(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.cloudflare_zero_trust_access_application." + rnd
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckCloudflareAccessApplicationAccountAUD(accountID, rnd, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "domain", rnd+"."+domain),
+					resource.TestCheckResourceAttrPair(name, "id", "cloudflare_zero_trust_access_application."+rnd, "id"),
+					resource.TestCheckResourceAttrPair(name, "aud", "cloudflare_zero_trust_access_application."+rnd, "aud"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckCloudflareAccessApplicationAccountAUD(accountID, name, domain string) string {
+	return fmt.Sprintf(`
+	resource "cloudflare_zero_trust_access_application" "%[1]s" {
+		account_id = "%[2]s"
+		name = "%[1]s"
+		domain = "%[1]s.%[3]s"
+	}
+
+	data "cloudflare_zero_trust_access_application" "%[1]s" {
+		account_id = "%[2]s"
+		aud = cloudflare_zero_trust_access_application.%[1]s.aud
+	}
+	`, name, accountID, domain)
+}
+
+func TestAccessApplicationDataSourceLookupByAUDAcrossPages(t *testing.T) {
+	t.Parallel()
+
+	var requestedPages []string
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+
+		result := `[{"id": "app-1", "name": "first", "domain": "first.example.com", "aud": "aud-1"}]`
+		switch page {
+		case "2":
+			result = `[{"id": "app-2", "name": "second", "domain": "second.example.com", "aud": "aud-2"}]`
+		case "3":
+			result = `[{"id": "app-3", "name": "third", "domain": "third.example.com", "aud": "aud-3"}]`
+		}
+		fmt.Fprintf(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": %s,
+			"result_info": {"page": %s, "per_page": 1, "count": 1, "total_count": 3, "total_pages": 3}
+		}`, result, page)
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceCloudflareZeroTrustAccessApplication().Schema, map[string]interface{}{
+		consts.AccountIDSchemaKey: "identifier",
+		"aud":                     "aud-2",
+	})
+	diags := dataSourceCloudflareAccessApplicationRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "app-2", d.Id())
+	assert.Equal(t, "second", d.Get("name"))
+	assert.Equal(t, "second.example.com", d.Get("domain"))
+	assert.Equal(t, []string{"1", "2"}, requestedPages)
+
+	requestedPages = nil
+	d = schema.TestResourceDataRaw(t, dataSourceCloudflareZeroTrustAccessApplication().Schema, map[string]interface{}{
+		consts.AccountIDSchemaKey: "identifier",
+		"aud":                     "aud-missing",
+	})
+	diags = dataSourceCloudflareAccessApplicationRead(context.Background(), d, client)
+	assert.True(t, diags.HasError())
+	assert.Equal(t, []string{"1", "2", "3"}, requestedPages)
+}
@@ -5,9 +5,11 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	cloudflare "github.com/cloudflare/cloudflare-go"
 	"github.com/cloudflare/terraform-provider-cloudflare/internal/consts"
@@ -16,6 +18,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -402,7 +405,6 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			i, _ := strconv.ParseInt(utils.GetDefaultFromEnv(consts.RPSEnvVarKey, consts.RPSDefault), 10, 64)
 			rps = i
 		}
-		limitOpt := cloudflare.UsingRateLimit(float64(rps))
 
 		if _, ok := d.GetOk(consts.RetriesSchemaKey); ok {
 			retries = int64(d.Get(consts.RetriesSchemaKey).(int))
@@ -452,8 +454,21 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 			return nil, diags
 		}
 
-		retryOpt := cloudflare.UsingRetryPolicy(int(retries), int(minBackOff), int(maxBackOff))
-		options := []cloudflare.Option{limitOpt, retryOpt, baseURL}
+		// The transport rate limits and retries every attempt, including rate
+		// limited responses honouring Retry-After. cloudflare-go would
+		// otherwise retry and rate limit the same requests a second time.
+		limitOpt := cloudflare.UsingRateLimit(float64(rate.Inf))
+		retryOpt := cloudflare.UsingRetryPolicy(0, int(minBackOff), int(maxBackOff))
+		httpClientOpt := cloudflare.HTTPClient(&http.Client{
+			Transport: newRetryTransport(
+				http.DefaultTransport,
+				rate.NewLimiter(rate.Limit(rps), 1),
+				int(retries),
+				time.Duration(minBackOff)*time.Second,
+				time.Duration(maxBackOff)*time.Second,
+			),
+		})
+		options := []cloudflare.Option{limitOpt, retryOpt, httpClientOpt, baseURL}
 
 		options = append(options, cloudflare.Debug(logging.IsDebugOrHigher()))
 
@@ -3,10 +3,13 @@ package sdkv2provider
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"testing"
 
+	cloudflare "github.com/cloudflare/cloudflare-go"
 	"github.com/cloudflare/terraform-provider-cloudflare/internal/consts"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tfsdkv2 "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -186,6 +189,27 @@ func testAccPreCheckBYOIPPrefix(t *testing.T) {
 	}
 }
 
+// newMockCloudflareClient returns an API client that talks to an httptest
+// server backed by handler. The server is closed when the test finishes and
+// the client's own retries are disabled; opts are applied after the defaults.
+func newMockCloudflareClient(t *testing.T, handler http.HandlerFunc, opts ...cloudflare.Option) *cloudflare.API {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts = append([]cloudflare.Option{
+		cloudflare.BaseURL(server.URL),
+		cloudflare.UsingRetryPolicy(0, 0, 0),
+	}, opts...)
+	client, err := cloudflare.NewWithAPIToken("0123456789012345678901234567890123456789", opts...)
+	if err != nil {
+		t.Fatalf("failed to create mock Cloudflare client: %s", err)
+	}
+
+	return client
+}
+
 func generateRandomResourceName() string {
 	return acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
 }
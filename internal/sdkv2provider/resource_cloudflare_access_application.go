@@ -4,19 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	cloudflare "github.com/cloudflare/cloudflare-go"
-	"github.com/cloudflare/terraform-provider-cloudflare/internal/consts"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCloudflareAccessApplication() *schema.Resource {
 	return &schema.Resource{
 		Schema:        resourceCloudflareAccessApplicationSchema(),
+		CustomizeDiff: resourceCloudflareAccessApplicationCustomizeDiff(),
 		CreateContext: resourceCloudflareAccessApplicationCreate,
 		ReadContext:   resourceCloudflareAccessApplicationRead,
 		UpdateContext: resourceCloudflareAccessApplicationUpdate,
@@ -24,6 +29,7 @@ func resourceCloudflareAccessApplication() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareAccessApplicationImport,
 		},
+		ValidateRawResourceConfigFuncs: resourceCloudflareAccessApplicationValidateRawConfig(),
 		Description: heredoc.Doc(`
 			Provides a Cloudflare Access Application resource. Access
 			Applications are used to restrict access to a whole application using an
@@ -36,6 +42,7 @@ func resourceCloudflareAccessApplication() *schema.Resource {
 func resourceCloudflareZeroTrustAccessApplication() *schema.Resource {
 	return &schema.Resource{
 		Schema:        resourceCloudflareAccessApplicationSchema(),
+		CustomizeDiff: resourceCloudflareAccessApplicationCustomizeDiff(),
 		CreateContext: resourceCloudflareAccessApplicationCreate,
 		ReadContext:   resourceCloudflareAccessApplicationRead,
 		UpdateContext: resourceCloudflareAccessApplicationUpdate,
@@ -43,6 +50,7 @@ func resourceCloudflareZeroTrustAccessApplication() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareAccessApplicationImport,
 		},
+		ValidateRawResourceConfigFuncs: resourceCloudflareAccessApplicationValidateRawConfig(),
 		Description: heredoc.Doc(`
 			Provides a Cloudflare Access Application resource. Access
 			Applications are used to restrict access to a whole application using an
@@ -51,6 +59,590 @@ func resourceCloudflareZeroTrustAccessApplication() *schema.Resource {
 	}
 }
 
+// resourceCloudflareAccessApplicationCustomizeDiff groups the plan time
+// checks shared by both Access Application resources.
+func resourceCloudflareAccessApplicationCustomizeDiff() schema.CustomizeDiffFunc {
+	return customdiff.All(
+		validateAccessApplicationRefreshTokenOptions,
+		validateAccessApplicationHybridAndImplicitOptions,
+		validateAccessApplicationCustomDeny,
+		validateAccessApplicationCORSHeaders,
+		validateAccessApplicationSCIMAuthentication,
+		validateAccessApplicationFooterLinks,
+		validateAccessApplicationAutoRedirectToIdentity,
+		validateAccessApplicationSaasSAMLOnlyFields,
+		validateAccessApplicationBookmarkApp,
+		validateAccessApplicationDomain,
+		validateAccessApplicationDuplicatePolicies,
+		validateAccessApplicationTags,
+		inferAccessApplicationDomainType,
+	)
+}
+
+// resourceCloudflareAccessApplicationValidateRawConfig groups the checks
+// shared by both Access Application resources that only warn. CustomizeDiff
+// functions cannot return warning diagnostics, so these run against the raw
+// configuration during validation instead.
+func resourceCloudflareAccessApplicationValidateRawConfig() []schema.ValidateRawResourceConfigFunc {
+	return []schema.ValidateRawResourceConfigFunc{
+		warnAccessApplicationSkipInterstitial,
+		warnAccessApplicationPKCEWithoutGrant,
+		warnAccessApplicationWithoutPolicies,
+	}
+}
+
+// validateAccessApplicationRefreshTokenOptions ensures that OIDC SaaS
+// applications issuing refresh tokens also define how those tokens behave,
+// and that those options are not set when no refresh tokens are issued.
+func validateAccessApplicationRefreshTokenOptions(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if saasAuthType(d) != saasAuthTypeOIDC || !d.NewValueKnown("saas_app.0.grant_types") {
+		return nil
+	}
+
+	grantTypes, ok := d.Get("saas_app.0.grant_types").(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	refreshTokenOptions := d.Get("saas_app.0.refresh_token_options").([]interface{})
+	if grantTypes.Contains(saasGrantTypeRefreshTokens) && len(refreshTokenOptions) == 0 {
+		return fmt.Errorf("saas_app.0.refresh_token_options must be configured when saas_app.0.grant_types includes %q", saasGrantTypeRefreshTokens)
+	}
+
+	if !grantTypes.Contains(saasGrantTypeRefreshTokens) && len(refreshTokenOptions) > 0 {
+		return fmt.Errorf("saas_app.0.refresh_token_options requires saas_app.0.grant_types to include %q", saasGrantTypeRefreshTokens)
+	}
+
+	return nil
+}
+
+// validateAccessApplicationHybridAndImplicitOptions ensures the hybrid and
+// implicit flow options are only configured when the application allows one
+// of those flows.
+func validateAccessApplicationHybridAndImplicitOptions(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if saasAuthType(d) != saasAuthTypeOIDC || !d.NewValueKnown("saas_app.0.grant_types") {
+		return nil
+	}
+
+	grantTypes, ok := d.Get("saas_app.0.grant_types").(*schema.Set)
+	if !ok || grantTypes.Contains(saasGrantTypeHybrid) || grantTypes.Contains(saasGrantTypeImplicit) {
+		return nil
+	}
+
+	if options := d.Get("saas_app.0.hybrid_and_implicit_options").([]interface{}); len(options) > 0 {
+		return fmt.Errorf("saas_app.0.hybrid_and_implicit_options requires saas_app.0.grant_types to include %q or %q", saasGrantTypeHybrid, saasGrantTypeImplicit)
+	}
+
+	return nil
+}
+
+// validateAccessApplicationCustomDeny rejects configurations setting both a
+// custom deny message and URL as the API only honours one of them.
+func validateAccessApplicationCustomDeny(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("custom_deny_message").(string) != "" && d.Get("custom_deny_url").(string) != "" {
+		return errors.New("only one of custom_deny_message or custom_deny_url can be configured, remove one of them")
+	}
+
+	return nil
+}
+
+// validateAccessApplicationCORSHeaders rejects cors_headers blocks that
+// neither list nor allow all methods, origins or headers, such a block
+// configures nothing.
+func validateAccessApplicationCORSHeaders(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("cors_headers") {
+		return nil
+	}
+
+	for _, corsHeaders := range d.Get("cors_headers").([]interface{}) {
+		corsHeadersMap, _ := corsHeaders.(map[string]interface{})
+		if accessApplicationCORSHeadersEmpty(corsHeadersMap) {
+			return errors.New("cors_headers must set at least one of allowed_methods, allowed_origins, allowed_headers, allow_all_methods, allow_all_origins or allow_all_headers")
+		}
+	}
+
+	return nil
+}
+
+func accessApplicationCORSHeadersEmpty(corsHeaders map[string]interface{}) bool {
+	for _, key := range []string{"allowed_methods", "allowed_origins", "allowed_headers"} {
+		if values, ok := corsHeaders[key].(*schema.Set); ok && values.Len() > 0 {
+			return false
+		}
+	}
+
+	for _, key := range []string{"allow_all_methods", "allow_all_origins", "allow_all_headers"} {
+		if allowAll, ok := corsHeaders[key].(bool); ok && allowAll {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateAccessApplicationSCIMAuthentication ensures an enabled SCIM
+// configuration can authenticate against the remote SCIM service, without it
+// no provisioning request succeeds.
+func validateAccessApplicationSCIMAuthentication(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("scim_config.0.enabled").(bool) || !d.NewValueKnown("scim_config.0.authentication") {
+		return nil
+	}
+
+	if authentication, _ := d.Get("scim_config.0.authentication").([]interface{}); len(authentication) == 0 {
+		return errors.New("scim_config.0.authentication must be configured when scim_config.0.enabled is true")
+	}
+
+	return nil
+}
+
+// validateAccessApplicationFooterLinks ensures every App Launcher footer link
+// with a URL also has a name to display.
+func validateAccessApplicationFooterLinks(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("footer_links") {
+		return nil
+	}
+
+	footerLinks, ok := d.Get("footer_links").(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	return accessApplicationFooterLinksError(footerLinks.List())
+}
+
+func accessApplicationFooterLinksError(footerLinks []interface{}) error {
+	for _, footerLink := range footerLinks {
+		footerLinkMap, _ := footerLink.(map[string]interface{})
+		url, _ := footerLinkMap["url"].(string)
+		name, _ := footerLinkMap["name"].(string)
+		if url != "" && name == "" {
+			return fmt.Errorf("footer_links with url %q must also set name", url)
+		}
+	}
+
+	return nil
+}
+
+// validateAccessApplicationAutoRedirectToIdentity ensures there is an
+// identity provider to redirect to when skipping the identity provider
+// selection page.
+func validateAccessApplicationAutoRedirectToIdentity(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("auto_redirect_to_identity").(bool) || !d.NewValueKnown("allowed_idps") {
+		return nil
+	}
+
+	if allowedIdps, ok := d.Get("allowed_idps").(*schema.Set); !ok || allowedIdps.Len() == 0 {
+		return errors.New("allowed_idps must contain at least one identity provider when auto_redirect_to_identity is enabled")
+	}
+
+	return nil
+}
+
+// validateAccessApplicationSaasSAMLOnlyFields rejects SAML specific settings
+// on OIDC SaaS applications, the API silently drops them.
+func validateAccessApplicationSaasSAMLOnlyFields(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if saasAuthType(d) != saasAuthTypeOIDC {
+		return nil
+	}
+
+	for _, field := range []string{"name_id_format", "name_id_transform_jsonata"} {
+		if d.Get(fmt.Sprintf("saas_app.0.%s", field)).(string) != "" {
+			return fmt.Errorf("saas_app.0.%s is only supported when saas_app.0.auth_type is %q", field, saasAuthTypeSAML)
+		}
+	}
+
+	return nil
+}
+
+// warnAccessApplicationSkipInterstitial warns when skip_interstitial is
+// enabled on an application type that never shows the CLI authorization
+// interstitial.
+func warnAccessApplicationSkipInterstitial(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	skipInterstitial, _ := getRawConfigBool(req.RawConfig, "skip_interstitial")
+	appType, ok := getRawConfigString(req.RawConfig, "type")
+	if !ok {
+		if !getRawValue("type", req.RawConfig).IsKnown() {
+			return
+		}
+		appType = "self_hosted"
+	}
+
+	if warning := accessApplicationSkipInterstitialWarning(appType, skipInterstitial); warning != "" {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       warning,
+			AttributePath: cty.GetAttrPath("skip_interstitial"),
+		})
+	}
+}
+
+func accessApplicationSkipInterstitialWarning(appType string, skipInterstitial bool) string {
+	if !skipInterstitial {
+		return ""
+	}
+
+	switch appType {
+	case "saas", "bookmark", "app_launcher":
+		return fmt.Sprintf("skip_interstitial has no effect on %q applications, it only applies to applications reached through the CLI such as ssh or self_hosted", appType)
+	}
+
+	return ""
+}
+
+// validateAccessApplicationBookmarkApp ensures bookmark_app is only used by
+// bookmark applications and is not combined with the top level attributes it
+// replaces.
+func validateAccessApplicationBookmarkApp(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if len(d.Get("bookmark_app").([]interface{})) == 0 {
+		return nil
+	}
+
+	if appType := d.Get("type").(string); appType != "bookmark" {
+		return fmt.Errorf("bookmark_app is only supported when type is \"bookmark\", got %q", appType)
+	}
+
+	// domain is computed, check the configuration instead of the planned value.
+	for _, attr := range []string{"domain", "logo_url"} {
+		if !getRawValue(attr, d.GetRawConfig()).IsNull() {
+			return fmt.Errorf("%s cannot be set together with bookmark_app, use bookmark_app.0.url and bookmark_app.0.logo_url instead", attr)
+		}
+	}
+
+	return nil
+}
+
+// validateAccessApplicationDomain ensures application types protecting a
+// hostname configure one, either as the domain or through destinations.
+func validateAccessApplicationDomain(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	appType := d.Get("type").(string)
+	if appType != "self_hosted" && appType != "ssh" && appType != "vnc" {
+		return nil
+	}
+
+	// domain is computed, check the configuration instead of the planned value.
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+
+	for _, attr := range []string{"domain", "destinations", "self_hosted_domains"} {
+		value := getRawValue(attr, config)
+		if value.IsNull() {
+			continue
+		}
+		if !value.IsKnown() || !value.CanIterateElements() || value.LengthInt() > 0 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("domain, destinations or self_hosted_domains must be configured for %q applications", appType)
+}
+
+// validateAccessApplicationTags rejects more tags than Cloudflare accepts on
+// a single application, which the API would otherwise only report on apply.
+func validateAccessApplicationTags(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return nil
+	}
+
+	if tags, ok := d.Get("tags").(*schema.Set); ok && tags.Len() > accessApplicationMaxTags {
+		return fmt.Errorf("tags lists %d tags but Cloudflare allows at most %d tags per Access Application", tags.Len(), accessApplicationMaxTags)
+	}
+
+	return nil
+}
+
+// validateAccessApplicationDuplicatePolicies rejects a policies list that
+// references the same policy more than once.
+func validateAccessApplicationDuplicatePolicies(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Check the configuration, policy IDs referencing other resources may
+	// not be known yet.
+	policies := getRawValue("policies", d.GetRawConfig())
+	if policies.IsNull() || !policies.IsKnown() || !policies.CanIterateElements() {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var duplicates []string
+	for _, policy := range policies.AsValueSlice() {
+		if policy.IsNull() || !policy.IsKnown() {
+			continue
+		}
+		policyID := policy.AsString()
+		if seen[policyID] && !contains(duplicates, policyID) {
+			duplicates = append(duplicates, policyID)
+		}
+		seen[policyID] = true
+	}
+
+	if len(duplicates) > 0 {
+		return fmt.Errorf("policies must not contain duplicates, found: %s", strings.Join(duplicates, ", "))
+	}
+
+	return nil
+}
+
+// inferAccessApplicationDomainType plans domain_type from the configured
+// domain when domain_type isn't configured, the same way the API classifies
+// it. An explicit value is always kept, and existing applications are only
+// reclassified when their domain changes.
+func inferAccessApplicationDomainType(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !getRawValue("domain_type", config).IsNull() {
+		return nil
+	}
+
+	domain, ok := getRawConfigString(config, "domain")
+	if !ok || domain == "" || (d.Id() != "" && !d.HasChange("domain")) {
+		return nil
+	}
+
+	if domainType := accessApplicationDomainType(domain); d.Get("domain_type").(string) != domainType {
+		return d.SetNew("domain_type", domainType)
+	}
+
+	return nil
+}
+
+// accessApplicationDomainType returns "private" for a domain that is an IP
+// address or CIDR, optionally followed by a path, and "public" otherwise.
+func accessApplicationDomainType(domain string) string {
+	if _, _, err := net.ParseCIDR(domain); err == nil {
+		return "private"
+	}
+
+	host, _, _ := strings.Cut(domain, "/")
+	if net.ParseIP(host) != nil {
+		return "private"
+	}
+
+	return "public"
+}
+
+// expandAccessApplicationBookmarkApp returns the domain and logo to send for
+// a bookmark application configured through the bookmark_app block.
+func expandAccessApplicationBookmarkApp(d *schema.ResourceData) (domain, logoURL string, ok bool) {
+	if d.Get("type").(string) != "bookmark" {
+		return "", "", false
+	}
+
+	if _, ok := d.GetOk("bookmark_app"); !ok {
+		return "", "", false
+	}
+
+	return d.Get("bookmark_app.0.url").(string), d.Get("bookmark_app.0.logo_url").(string), true
+}
+
+// warnAccessApplicationPKCEWithoutGrant warns when PKCE without a client
+// secret is allowed but the PKCE flow is not enabled. grant_types is computed
+// by the API when unset, so the check only runs when it is configured.
+func warnAccessApplicationPKCEWithoutGrant(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	authType, _ := getRawConfigString(req.RawConfig, "saas_app.0.auth_type")
+	allowPKCEWithoutClientSecret, _ := getRawConfigBool(req.RawConfig, "saas_app.0.allow_pkce_without_client_secret")
+	if !strings.EqualFold(authType, saasAuthTypeOIDC) || !allowPKCEWithoutClientSecret {
+		return
+	}
+
+	rawGrantTypes := getRawValue("saas_app.0.grant_types", req.RawConfig)
+	if rawGrantTypes.IsNull() || !rawGrantTypes.IsWhollyKnown() || !rawGrantTypes.CanIterateElements() {
+		return
+	}
+
+	grantTypes := schema.NewSet(schema.HashString, nil)
+	for _, grantType := range rawGrantTypes.AsValueSlice() {
+		if !grantType.IsNull() && grantType.Type().Equals(cty.String) {
+			grantTypes.Add(grantType.AsString())
+		}
+	}
+
+	if warning := accessApplicationPKCEWithoutGrantWarning(allowPKCEWithoutClientSecret, grantTypes); warning != "" {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       warning,
+			AttributePath: cty.GetAttrPath("saas_app").IndexInt(0).GetAttr("allow_pkce_without_client_secret"),
+		})
+	}
+}
+
+func accessApplicationPKCEWithoutGrantWarning(allowPKCEWithoutClientSecret bool, grantTypes *schema.Set) string {
+	if !allowPKCEWithoutClientSecret || grantTypes.Contains(saasGrantTypePKCE) {
+		return ""
+	}
+
+	return fmt.Sprintf("saas_app.0.allow_pkce_without_client_secret has no effect unless saas_app.0.grant_types includes %q", saasGrantTypePKCE)
+}
+
+// warnAccessApplicationWithoutPolicies warns when an application type that
+// needs a policy to be reachable sets `policies` to an empty list. Leaving
+// `policies` unset is the way to manage policies through
+// `cloudflare_access_policy`, so that is never warned about.
+func warnAccessApplicationWithoutPolicies(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	policies := getRawValue("policies", req.RawConfig)
+	if policies.IsNull() || !policies.IsKnown() || !policies.CanIterateElements() {
+		return
+	}
+	policyCount := policies.LengthInt()
+
+	appType, ok := getRawConfigString(req.RawConfig, "type")
+	if !ok {
+		if !getRawValue("type", req.RawConfig).IsKnown() {
+			return
+		}
+		appType = "self_hosted"
+	}
+
+	if warning := accessApplicationWithoutPoliciesWarning(appType, policyCount); warning != "" {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       warning,
+			AttributePath: cty.GetAttrPath("policies"),
+		})
+	}
+}
+
+func accessApplicationWithoutPoliciesWarning(appType string, policyCount int) string {
+	if policyCount > 0 {
+		return ""
+	}
+
+	switch appType {
+	case "self_hosted", "ssh", "vnc", "saas":
+		return fmt.Sprintf("no policies are attached to the %q application through policies, make sure policies are attached through cloudflare_access_policy instead", appType)
+	}
+
+	return ""
+}
+
+// accessApplicationOIDCDiscoveryURL builds the OpenID Connect discovery
+// endpoint of an OIDC SaaS application. It is served from the auth domain of
+// the Access organization, which is not part of the application response, so
+// the URL already in state is reused while the client ID is unchanged and the
+// organization is only fetched when it is missing.
+func accessApplicationOIDCDiscoveryURL(ctx context.Context, client *cloudflare.API, identifier *cloudflare.ResourceContainer, d *schema.ResourceData, clientID string) (string, error) {
+	if clientID == "" {
+		return "", nil
+	}
+
+	if d.Get("saas_app.0.client_id").(string) == clientID {
+		if discoveryURL := d.Get("saas_app.0.oidc_discovery_url").(string); discoveryURL != "" {
+			return discoveryURL, nil
+		}
+	}
+
+	organization, _, err := client.GetAccessOrganization(ctx, identifier, cloudflare.GetAccessOrganizationParams{})
+	if err != nil {
+		return "", fmt.Errorf("error fetching Access organization: %w", err)
+	}
+
+	if organization.AuthDomain == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("https://%s/cdn-cgi/access/sso/oidc/%s/.well-known/openid-configuration", organization.AuthDomain, clientID), nil
+}
+
+// wrapAccessApplicationDestinationsError adds a hint to 403 responses for
+// applications with private destinations. They are gated behind an early
+// access feature on the account, but the API does not return a dedicated error
+// code for it, so the hint is phrased as a possible cause rather than a
+// diagnosis.
+func wrapAccessApplicationDestinationsError(err error, destinations []cloudflare.AccessDestination) error {
+	hasPrivateDestination := false
+	for _, destination := range destinations {
+		if destination.Type == cloudflare.AccessDestinationPrivate {
+			hasPrivateDestination = true
+			break
+		}
+	}
+	if !hasPrivateDestination {
+		return err
+	}
+
+	// cloudflare-go surfaces 403 Forbidden responses as AuthenticationError.
+	var forbiddenError *cloudflare.AuthenticationError
+	if errors.As(err, &forbiddenError) {
+		return fmt.Errorf("access denied creating an application with private destinations, which may require the private destinations early access feature to be enabled on the account: %w", err)
+	}
+
+	return err
+}
+
+// checkAccessApplicationPoliciesUnchanged re-reads the policies attached to
+// an application and errors if they no longer match the ones Terraform last
+// saw. The API only supports replacing the full list and has no conditional
+// update, so this narrows the window in which a concurrent apply is clobbered.
+func checkAccessApplicationPoliciesUnchanged(ctx context.Context, client *cloudflare.API, identifier *cloudflare.ResourceContainer, appID string, expected []string) error {
+	current, err := client.GetAccessApplication(ctx, identifier, appID)
+	if err != nil {
+		return fmt.Errorf("error fetching Access Application %q policies: %w", appID, err)
+	}
+
+	currentIDs := make([]string, len(current.Policies))
+	for i := range current.Policies {
+		currentIDs[i] = current.Policies[i].ID
+	}
+
+	if !reflect.DeepEqual(currentIDs, expected) {
+		return fmt.Errorf("policies of Access Application %q were modified outside of this apply (expected %v, found %v), refresh the state and try again", appID, expected, currentIDs)
+	}
+
+	return nil
+}
+
+// createMissingAccessTags creates the tags that don't exist yet so they can
+// be attached to the application, which otherwise rejects unknown tags.
+func createMissingAccessTags(ctx context.Context, client *cloudflare.API, identifier *cloudflare.ResourceContainer, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	existingTags, err := client.ListAccessTags(ctx, identifier, cloudflare.ListAccessTagsParams{})
+	if err != nil {
+		return fmt.Errorf("error listing Access Tags for %s %q: %w", identifier.Level, identifier.Identifier, err)
+	}
+
+	existing := make(map[string]bool, len(existingTags))
+	for _, tag := range existingTags {
+		existing[tag.Name] = true
+	}
+
+	for _, tag := range tags {
+		if existing[tag] {
+			continue
+		}
+		if _, err := client.CreateAccessTag(ctx, identifier, cloudflare.CreateAccessTagParams{Name: tag}); err != nil {
+			return fmt.Errorf("error creating Access Tag %q for %s %q: %w", tag, identifier.Level, identifier.Identifier, err)
+		}
+		existing[tag] = true
+	}
+
+	return nil
+}
+
+// accessApplicationAllIdentityProviders is the allowed_idps value that stands
+// for every identity provider of the account or zone.
+const accessApplicationAllIdentityProviders = "*"
+
+// resolveAccessApplicationAllowedIdps expands the "*" sentinel to the IDs of
+// all identity providers, other values are returned unchanged.
+func resolveAccessApplicationAllowedIdps(ctx context.Context, client *cloudflare.API, identifier *cloudflare.ResourceContainer, allowedIdps []string) ([]string, error) {
+	if !contains(allowedIdps, accessApplicationAllIdentityProviders) {
+		return allowedIdps, nil
+	}
+
+	identityProviders, _, err := client.ListAccessIdentityProviders(ctx, identifier, cloudflare.ListAccessIdentityProvidersParams{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing Access Identity Providers for %s %q: %w", identifier.Level, identifier.Identifier, err)
+	}
+
+	ids := make([]string, 0, len(identityProviders))
+	for _, identityProvider := range identityProviders {
+		ids = append(ids, identityProvider.ID)
+	}
+
+	return ids, nil
+}
+
 func resourceCloudflareAccessApplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*cloudflare.API)
 
@@ -79,6 +671,10 @@ func resourceCloudflareAccessApplicationCreate(ctx context.Context, d *schema.Re
 		newAccessApplication.AllowAuthenticateViaWarp = cloudflare.BoolPtr(d.Get("allow_authenticate_via_warp").(bool))
 	}
 
+	if value, ok := d.GetOk("domain_type"); ok {
+		newAccessApplication.DomainType = cloudflare.AccessDestinationType(value.(string))
+	}
+
 	if value, ok := d.GetOk("allowed_idps"); ok {
 		newAccessApplication.AllowedIdps = expandInterfaceToStringList(value.(*schema.Set).List())
 	}
@@ -135,6 +731,11 @@ func resourceCloudflareAccessApplicationCreate(ctx context.Context, d *schema.Re
 		newAccessApplication.SCIMConfig = convertSCIMConfigSchemaToStruct(d)
 	}
 
+	if domain, logoURL, ok := expandAccessApplicationBookmarkApp(d); ok {
+		newAccessApplication.Domain = domain
+		newAccessApplication.LogoURL = logoURL
+	}
+
 	if appType == "app_launcher" {
 		newAccessApplication.AccessAppLauncherCustomization = cloudflare.AccessAppLauncherCustomization{
 			LogoURL:                  d.Get("app_launcher_logo_url").(string),
@@ -164,9 +765,22 @@ func resourceCloudflareAccessApplicationCreate(ctx context.Context, d *schema.Re
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	if d.Get("create_missing_tags").(bool) {
+		if err := createMissingAccessTags(ctx, client, identifier, newAccessApplication.Tags); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	newAccessApplication.AllowedIdps, err = resolveAccessApplicationAllowedIdps(ctx, client, identifier, newAccessApplication.AllowedIdps)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	accessApplication, err := client.CreateAccessApplication(ctx, identifier, newAccessApplication)
 
 	if err != nil {
+		err = wrapAccessApplicationDestinationsError(err, newAccessApplication.Destinations)
 		return diag.FromErr(fmt.Errorf("error creating Access Application for %s %q: %w", identifier.Level, identifier.Identifier, err))
 	}
 
@@ -214,6 +828,12 @@ func resourceCloudflareAccessApplicationRead(ctx context.Context, d *schema.Reso
 
 	d.Set("name", accessApplication.Name)
 	d.Set("aud", accessApplication.AUD)
+	if accessApplication.CreatedAt != nil {
+		d.Set("created_at", accessApplication.CreatedAt.Format(time.RFC3339))
+	}
+	if accessApplication.UpdatedAt != nil {
+		d.Set("updated_at", accessApplication.UpdatedAt.Format(time.RFC3339))
+	}
 	d.Set("session_duration", accessApplication.SessionDuration)
 	if _, domainWasSet := d.GetOk("domain"); domainWasSet {
 		// Only set the domain if it was set in the configuration, as apps can be created without a domain
@@ -222,17 +842,40 @@ func resourceCloudflareAccessApplicationRead(ctx context.Context, d *schema.Reso
 	} else {
 		d.Set("domain", nil)
 	}
+	if accessApplication.DomainType != "" {
+		d.Set("domain_type", accessApplication.DomainType)
+	}
 	d.Set("type", accessApplication.Type)
 	d.Set("auto_redirect_to_identity", accessApplication.AutoRedirectToIdentity)
 	d.Set("enable_binding_cookie", accessApplication.EnableBindingCookie)
 	d.Set("custom_deny_message", accessApplication.CustomDenyMessage)
 	d.Set("custom_deny_url", accessApplication.CustomDenyURL)
 	d.Set("custom_non_identity_deny_url", accessApplication.CustomNonIdentityDenyURL)
-	d.Set("allowed_idps", accessApplication.AllowedIdps)
+	allowedIdps := accessApplication.AllowedIdps
+	if priorIdps, ok := d.Get("allowed_idps").(*schema.Set); ok && priorIdps.Contains(accessApplicationAllIdentityProviders) {
+		// Keep the sentinel while the application still allows every
+		// identity provider, a new one shows up as a diff.
+		allIdps, err := resolveAccessApplicationAllowedIdps(ctx, client, identifier, []string{accessApplicationAllIdentityProviders})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if schema.NewSet(schema.HashString, flattenStringList(allIdps)).Equal(schema.NewSet(schema.HashString, flattenStringList(allowedIdps))) {
+			allowedIdps = []string{accessApplicationAllIdentityProviders}
+		}
+	}
+	d.Set("allowed_idps", allowedIdps)
+	d.Set("resolved_allowed_idps", accessApplication.AllowedIdps)
 	d.Set("http_only_cookie_attribute", cloudflare.Bool(accessApplication.HttpOnlyCookieAttribute))
 	d.Set("same_site_cookie_attribute", accessApplication.SameSiteCookieAttribute)
 	d.Set("skip_interstitial", accessApplication.SkipInterstitial)
-	d.Set("logo_url", accessApplication.LogoURL)
+	if _, ok := d.GetOk("bookmark_app"); ok && accessApplication.Type == "bookmark" {
+		d.Set("bookmark_app", []interface{}{map[string]interface{}{
+			"url":      accessApplication.Domain,
+			"logo_url": accessApplication.LogoURL,
+		}})
+	} else {
+		d.Set("logo_url", accessApplication.LogoURL)
+	}
 	d.Set("app_launcher_visible", accessApplication.AppLauncherVisible)
 	d.Set("service_auth_401_redirect", accessApplication.ServiceAuth401Redirect)
 	d.Set("custom_pages", accessApplication.CustomPages)
@@ -263,7 +906,20 @@ func resourceCloudflareAccessApplicationRead(ctx context.Context, d *schema.Reso
 		return diag.FromErr(fmt.Errorf("error setting Access Application CORS header configuration: %w", corsConfigErr))
 	}
 
+	var diags diag.Diagnostics
 	saasConfig := convertSaasStructToSchema(d, accessApplication.SaasApplication)
+	if len(saasConfig) > 0 && accessApplication.SaasApplication.AuthType == saasAuthTypeOIDC {
+		discoveryURL, err := accessApplicationOIDCDiscoveryURL(ctx, client, identifier, d, accessApplication.SaasApplication.ClientID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "Unable to determine the OIDC discovery URL",
+				Detail:        fmt.Sprintf("saas_app.0.oidc_discovery_url is left empty until the next refresh: %s", err),
+				AttributePath: cty.GetAttrPath("saas_app").IndexInt(0).GetAttr("oidc_discovery_url"),
+			})
+		}
+		saasConfig[0].(map[string]interface{})["oidc_discovery_url"] = discoveryURL
+	}
 	if saasConfigErr := d.Set("saas_app", saasConfig); saasConfigErr != nil {
 		return diag.FromErr(fmt.Errorf("error setting Access Application SaaS app configuration: %w", saasConfigErr))
 	}
@@ -288,6 +944,12 @@ func resourceCloudflareAccessApplicationRead(ctx context.Context, d *schema.Reso
 	}
 
 	scimConfig := convertScimConfigStructToSchema(accessApplication.SCIMConfig)
+	if len(scimConfig) > 0 {
+		if priorAuth, ok := d.Get("scim_config.0.authentication").([]interface{}); ok {
+			config := scimConfig[0].(map[string]interface{})
+			config["authentication"] = sortByPriorOrder(config["authentication"].([]interface{}), priorAuth, "scheme")
+		}
+	}
 
 	if scimConfigErr := d.Set("scim_config", scimConfig); scimConfigErr != nil {
 		return diag.FromErr(fmt.Errorf("error setting Access Application SCIM configuration: %w", scimConfigErr))
@@ -301,7 +963,7 @@ func resourceCloudflareAccessApplicationRead(ctx context.Context, d *schema.Reso
 		d.Set("policies", policyIDs)
 	}
 
-	return nil
+	return diags
 }
 
 func resourceCloudflareAccessApplicationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -333,6 +995,10 @@ func resourceCloudflareAccessApplicationUpdate(ctx context.Context, d *schema.Re
 		updatedAccessApplication.AllowAuthenticateViaWarp = cloudflare.BoolPtr(d.Get("allow_authenticate_via_warp").(bool))
 	}
 
+	if value, ok := d.GetOk("domain_type"); ok {
+		updatedAccessApplication.DomainType = cloudflare.AccessDestinationType(value.(string))
+	}
+
 	if appType != "saas" {
 		updatedAccessApplication.Domain = d.Get("domain").(string)
 	}
@@ -399,6 +1065,11 @@ func resourceCloudflareAccessApplicationUpdate(ctx context.Context, d *schema.Re
 		updatedAccessApplication.SCIMConfig = convertSCIMConfigSchemaToStruct(d)
 	}
 
+	if domain, logoURL, ok := expandAccessApplicationBookmarkApp(d); ok {
+		updatedAccessApplication.Domain = domain
+		updatedAccessApplication.LogoURL = logoURL
+	}
+
 	if appType == "app_launcher" {
 		updatedAccessApplication.AccessAppLauncherCustomization = cloudflare.AccessAppLauncherCustomization{
 			LogoURL:               d.Get("app_launcher_logo_url").(string),
@@ -424,8 +1095,29 @@ func resourceCloudflareAccessApplicationUpdate(ctx context.Context, d *schema.Re
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("policies") {
+		oldPolicies, _ := d.GetChange("policies")
+		if expected := expandInterfaceToStringList(oldPolicies); len(expected) > 0 {
+			if err := checkAccessApplicationPoliciesUnchanged(ctx, client, identifier, d.Id(), expected); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.Get("create_missing_tags").(bool) {
+		if err := createMissingAccessTags(ctx, client, identifier, updatedAccessApplication.Tags); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	updatedAccessApplication.AllowedIdps, err = resolveAccessApplicationAllowedIdps(ctx, client, identifier, updatedAccessApplication.AllowedIdps)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	accessApplication, err := client.UpdateAccessApplication(ctx, identifier, updatedAccessApplication)
 	if err != nil {
+		err = wrapAccessApplicationDestinationsError(err, updatedAccessApplication.Destinations)
 		return diag.FromErr(fmt.Errorf("error updating Access Application for %s %q: %w", identifier.Level, identifier.Identifier, err))
 	}
 
@@ -461,18 +1153,36 @@ func resourceCloudflareAccessApplicationDelete(ctx context.Context, d *schema.Re
 }
 
 func resourceCloudflareAccessApplicationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	attributes := strings.SplitN(d.Id(), "/", 2)
-
-	if len(attributes) != 2 {
-		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/accessApplicationID\"", d.Id())
+	client := meta.(*cloudflare.API)
+	attributes := strings.SplitN(d.Id(), "/", 3)
+
+	var identifierType, identifierID, accessApplicationID string
+	switch len(attributes) {
+	case 3:
+		identifierType, identifierID, accessApplicationID = attributes[0], attributes[1], attributes[2]
+		if !contains([]string{"zone", "account"}, identifierType) {
+			return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"account/accountID/accessApplicationID\", \"zone/zoneID/accessApplicationID\" or \"accountID/accessApplicationID\"", d.Id())
+		}
+	case 2:
+		// Without an explicit scope, look the application up in the account
+		// first and fall back to a zone with the same identifier.
+		identifierType, identifierID, accessApplicationID = "account", attributes[0], attributes[1]
+		if _, err := client.GetAccessApplication(ctx, cloudflare.AccountIdentifier(identifierID), accessApplicationID); err != nil {
+			if _, zoneErr := client.GetAccessApplication(ctx, cloudflare.ZoneIdentifier(identifierID), accessApplicationID); zoneErr != nil {
+				return nil, fmt.Errorf("failed to find Access Application %q in account or zone %q: %w", accessApplicationID, identifierID, err)
+			}
+			identifierType = "zone"
+		}
+	default:
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"account/accountID/accessApplicationID\", \"zone/zoneID/accessApplicationID\" or \"accountID/accessApplicationID\"", d.Id())
 	}
 
-	accountID, accessApplicationID := attributes[0], attributes[1]
-
-	tflog.Debug(ctx, fmt.Sprintf("Importing Cloudflare Access Application: id %s for account %s", accessApplicationID, accountID))
+	tflog.Debug(ctx, fmt.Sprintf("Importing Cloudflare Access Application: id %s for %s %s", accessApplicationID, identifierType, identifierID))
 
-	d.Set(consts.AccountIDSchemaKey, accountID)
+	//lintignore:R001
+	d.Set(fmt.Sprintf("%s_id", identifierType), identifierID)
 	d.SetId(accessApplicationID)
+	d.Set("create_missing_tags", false)
 
 	resourceCloudflareAccessApplicationRead(ctx, d, meta)
 
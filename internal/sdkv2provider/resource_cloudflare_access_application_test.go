@@ -2,18 +2,27 @@ package sdkv2provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/cloudflare/terraform-provider-cloudflare/internal/consts"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tfsdkv2 "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -99,6 +108,8 @@ func TestAccCloudflareAccessApplication_BasicZone(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
 					resource.TestCheckResourceAttr(name, "allow_authenticate_via_warp", "false"),
 					resource.TestCheckResourceAttr(name, "options_preflight_bypass", "false"),
+					resource.TestMatchResourceAttr(name, "created_at", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+					resource.TestMatchResourceAttr(name, "updated_at", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
 				),
 			},
 		},
@@ -130,12 +141,89 @@ func TestAccCloudflareAccessApplication_BasicAccount(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
 					resource.TestCheckResourceAttr(name, "allow_authenticate_via_warp", "false"),
 					resource.TestCheckResourceAttr(name, "options_preflight_bypass", "false"),
+					resource.TestMatchResourceAttr(name, "created_at", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+					resource.TestMatchResourceAttr(name, "updated_at", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
 				),
 			},
 		},
 	})
 }
 
+func TestAccCloudflareAccessApplication_BasicAccountImport(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigBasic(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
+				),
+			},
+			{
+				ImportState:         true,
+				ImportStatePersist:  true,
+				ResourceName:        name,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", accountID),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigBasic(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ResourceName:        name,
+				ImportStateIdPrefix: fmt.Sprintf("account/%s/", accountID),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_BasicZoneImport(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigBasic(rnd, domain, cloudflare.ZoneIdentifier(zoneID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.ZoneIDSchemaKey, zoneID),
+				),
+			},
+			{
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ResourceName:        name,
+				ImportStateIdPrefix: fmt.Sprintf("zone/%s/", zoneID),
+			},
+			{
+				// Without a scope prefix the zone is found after the account
+				// lookup fails.
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ResourceName:        name,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", zoneID),
+			},
+		},
+	})
+}
+
 func TestAccCloudflareAccessApplication_WithSCIMConfigHttpBasic(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
@@ -158,20 +246,22 @@ func TestAccCloudflareAccessApplication_WithSCIMConfigHttpBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
 					resource.TestCheckResourceAttr(name, "scim_config.#", "1"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "scim.com"),
+					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "https://scim.com"),
 					resource.TestCheckResourceAttrPair(name, "scim_config.0.idp_uid", idpName, "id"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.deactivate_on_delete", "true"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.scheme", "httpbasic"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.user", "test"),
 					resource.TestCheckResourceAttrSet(name, "scim_config.0.authentication.0.password"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.schema", "urn:ietf:params:scim:schemas:core:2.0:User"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.filter", "title pr or userType eq \"Intern\""),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.transform_jsonata", "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.create", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.update", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.delete", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.strictness", "passthrough"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "scim_config.0.mappings.*", map[string]string{
+						"schema":              "urn:ietf:params:scim:schemas:core:2.0:User",
+						"enabled":             "true",
+						"filter":              "title pr or userType eq \"Intern\"",
+						"transform_jsonata":   "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])",
+						"operations.0.create": "true",
+						"operations.0.update": "true",
+						"operations.0.delete": "true",
+						"strictness":          "passthrough",
+					}),
 				),
 			},
 		},
@@ -200,19 +290,21 @@ func TestAccCloudflareAccessApplication_UpdateSCIMConfig(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
 					resource.TestCheckResourceAttr(name, "scim_config.#", "1"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "scim.com"),
+					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "https://scim.com"),
 					resource.TestCheckResourceAttrPair(name, "scim_config.0.idp_uid", idpName, "id"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.deactivate_on_delete", "true"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.scheme", "httpbasic"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.user", "test"),
 					resource.TestCheckResourceAttrSet(name, "scim_config.0.authentication.0.password"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.schema", "urn:ietf:params:scim:schemas:core:2.0:User"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.filter", "title pr or userType eq \"Intern\""),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.transform_jsonata", "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.create", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.update", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.delete", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "scim_config.0.mappings.*", map[string]string{
+						"schema":              "urn:ietf:params:scim:schemas:core:2.0:User",
+						"enabled":             "true",
+						"filter":              "title pr or userType eq \"Intern\"",
+						"transform_jsonata":   "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])",
+						"operations.0.create": "true",
+						"operations.0.update": "true",
+						"operations.0.delete": "true",
+					}),
 				),
 			},
 			{
@@ -225,7 +317,7 @@ func TestAccCloudflareAccessApplication_UpdateSCIMConfig(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
 					resource.TestCheckResourceAttr(name, "scim_config.#", "1"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.enabled", "false"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "scim2.com"),
+					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "https://scim2.com"),
 					resource.TestCheckResourceAttrPair(name, "scim_config.0.idp_uid", idpName, "id"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.deactivate_on_delete", "false"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.scheme", "oauthbearertoken"),
@@ -237,6 +329,216 @@ func TestAccCloudflareAccessApplication_UpdateSCIMConfig(t *testing.T) {
 	})
 }
 
+func TestAccCloudflareAccessApplication_SCIMConfigDefaultDeactivateOnDelete(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationSCIMConfigWithoutDeactivateOnDelete(rnd, accountID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "scim_config.#", "1"),
+					resource.TestCheckResourceAttrSet(name, "scim_config.0.deactivate_on_delete"),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationSCIMConfigWithoutDeactivateOnDelete(rnd, accountID, domain),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestConvertSCIMConfigOmitsUnsetDeactivateOnDelete(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		"scim_config": []interface{}{
+			map[string]interface{}{
+				"enabled":    true,
+				"remote_uri": "https://scim.com",
+				"idp_uid":    "idp-id",
+			},
+		},
+	})
+
+	scimConfig := convertSCIMConfigSchemaToStruct(d)
+	assert.Nil(t, scimConfig.DeactivateOnDelete)
+	assert.Equal(t, "https://scim.com", scimConfig.RemoteURI)
+}
+
+func TestConvertSCIMConfigDefaultsEnabledToFalse(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		"scim_config": []interface{}{
+			map[string]interface{}{
+				"remote_uri": "https://scim.com",
+				"idp_uid":    "idp-id",
+			},
+		},
+	})
+
+	scimConfig := convertSCIMConfigSchemaToStruct(d)
+	assert.Equal(t, cloudflare.BoolPtr(false), scimConfig.Enabled)
+}
+
+func TestAccessApplicationSCIMSecretsAreSensitive(t *testing.T) {
+	t.Parallel()
+
+	scimConfig := resourceCloudflareAccessApplicationSchema()["scim_config"].Elem.(*schema.Resource).Schema
+	authentication := scimConfig["authentication"].Elem.(*schema.Resource).Schema
+	for _, key := range []string{"password", "token", "client_secret"} {
+		assert.True(t, authentication[key].Sensitive, "scim_config.0.authentication.0.%s", key)
+		assert.Equal(t, CONCEALED_STRING, authentication[key].StateFunc("secret"), "scim_config.0.authentication.0.%s", key)
+	}
+}
+
+func TestAccCloudflareAccessApplication_SCIMConfigMappingDefaultEnabled(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationSCIMConfigMappingWithoutEnabled(rnd, accountID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "scim_config.0.mappings.*", map[string]string{
+						"schema":  "urn:ietf:params:scim:schemas:core:2.0:User",
+						"enabled": "true",
+					}),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationSCIMConfigMappingWithoutEnabled(rnd, accountID, domain),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestConvertSCIMConfigOmitsUnsetMappingEnabled(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		"scim_config": []interface{}{
+			map[string]interface{}{
+				"enabled":    true,
+				"remote_uri": "https://scim.com",
+				"idp_uid":    "idp-id",
+				"mappings": []interface{}{
+					map[string]interface{}{
+						"schema": "urn:ietf:params:scim:schemas:core:2.0:User",
+						"filter": "title pr",
+					},
+				},
+			},
+		},
+	})
+
+	scimConfig := convertSCIMConfigSchemaToStruct(d)
+	assert.Len(t, scimConfig.Mappings, 1)
+	assert.Nil(t, scimConfig.Mappings[0].Enabled)
+	assert.Equal(t, "title pr", scimConfig.Mappings[0].Filter)
+}
+
+func TestAccCloudflareAccessApplication_SCIMConfigDefaultEnabled(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationSCIMConfigWithoutEnabled(rnd, accountID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "scim_config.#", "1"),
+					resource.TestCheckResourceAttr(name, "scim_config.0.enabled", "false"),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationSCIMConfigWithoutEnabled(rnd, accountID, domain),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_SCIMConfigMappingsReordered(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationSCIMConfigMultipleMappings(rnd, accountID, domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "scim_config.0.mappings.*", map[string]string{
+						"schema": "urn:ietf:params:scim:schemas:core:2.0:User",
+						"filter": "title pr or userType eq \"Intern\"",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "scim_config.0.mappings.*", map[string]string{
+						"schema": "urn:ietf:params:scim:schemas:core:2.0:Group",
+						"filter": "displayName sw \"Engineering\"",
+					}),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationSCIMConfigMultipleMappings(rnd, accountID, domain, true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_SCIMConfigEnabledWithoutAuthentication(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationSCIMConfigWithoutAuthentication(rnd, accountID, domain),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("scim_config.0.authentication must be configured when scim_config.0.enabled is true")),
+			},
+		},
+	})
+}
+
 func TestAccCloudflareAccessApplication_WithSCIMConfigInvalidMappingSchema(t *testing.T) {
 	rnd := generateRandomResourceName()
 
@@ -277,18 +579,20 @@ func TestAccCloudflareAccessApplication_WithSCIMConfigOAuthBearerToken(t *testin
 					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
 					resource.TestCheckResourceAttr(name, "scim_config.#", "1"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "scim.com"),
+					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "https://scim.com"),
 					resource.TestCheckResourceAttrPair(name, "scim_config.0.idp_uid", idpName, "id"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.deactivate_on_delete", "true"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.scheme", "oauthbearertoken"),
 					resource.TestCheckResourceAttrSet(name, "scim_config.0.authentication.0.token"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.schema", "urn:ietf:params:scim:schemas:core:2.0:User"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.filter", "title pr or userType eq \"Intern\""),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.transform_jsonata", "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.create", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.update", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.delete", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "scim_config.0.mappings.*", map[string]string{
+						"schema":              "urn:ietf:params:scim:schemas:core:2.0:User",
+						"enabled":             "true",
+						"filter":              "title pr or userType eq \"Intern\"",
+						"transform_jsonata":   "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])",
+						"operations.0.create": "true",
+						"operations.0.update": "true",
+						"operations.0.delete": "true",
+					}),
 				),
 			},
 		},
@@ -317,7 +621,7 @@ func TestAccCloudflareAccessApplication_WithSCIMConfigMultiAuth(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
 					resource.TestCheckResourceAttr(name, "scim_config.#", "1"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "scim.com"),
+					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "https://scim.com"),
 					resource.TestCheckResourceAttrPair(name, "scim_config.0.idp_uid", idpName, "id"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.deactivate_on_delete", "true"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.scheme", "oauthbearertoken"),
@@ -325,13 +629,15 @@ func TestAccCloudflareAccessApplication_WithSCIMConfigMultiAuth(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.1.scheme", "access_service_token"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.1.client_id", "1234"),
 					resource.TestCheckResourceAttrSet(name, "scim_config.0.authentication.1.client_secret"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.schema", "urn:ietf:params:scim:schemas:core:2.0:User"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.filter", "title pr or userType eq \"Intern\""),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.transform_jsonata", "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.create", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.update", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.delete", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "scim_config.0.mappings.*", map[string]string{
+						"schema":              "urn:ietf:params:scim:schemas:core:2.0:User",
+						"enabled":             "true",
+						"filter":              "title pr or userType eq \"Intern\"",
+						"transform_jsonata":   "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])",
+						"operations.0.create": "true",
+						"operations.0.update": "true",
+						"operations.0.delete": "true",
+					}),
 				),
 			},
 		},
@@ -360,7 +666,7 @@ func TestAccCloudflareAccessApplication_WithSCIMConfigOAuth2(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
 					resource.TestCheckResourceAttr(name, "scim_config.#", "1"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "scim.com"),
+					resource.TestCheckResourceAttr(name, "scim_config.0.remote_uri", "https://scim.com"),
 					resource.TestCheckResourceAttrPair(name, "scim_config.0.idp_uid", idpName, "id"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.deactivate_on_delete", "true"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.scheme", "oauth2"),
@@ -370,13 +676,15 @@ func TestAccCloudflareAccessApplication_WithSCIMConfigOAuth2(t *testing.T) {
 					resource.TestCheckTypeSetElemAttr(name, "scim_config.0.authentication.0.scopes.*", "read"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.scopes.#", "1"),
 					resource.TestCheckResourceAttr(name, "scim_config.0.authentication.0.token_url", "https://www.token.com"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.schema", "urn:ietf:params:scim:schemas:core:2.0:User"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.enabled", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.filter", "title pr or userType eq \"Intern\""),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.transform_jsonata", "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.create", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.update", "true"),
-					resource.TestCheckResourceAttr(name, "scim_config.0.mappings.0.operations.0.delete", "true"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "scim_config.0.mappings.*", map[string]string{
+						"schema":              "urn:ietf:params:scim:schemas:core:2.0:User",
+						"enabled":             "true",
+						"filter":              "title pr or userType eq \"Intern\"",
+						"transform_jsonata":   "$merge([$, {'userName': $substringBefore($.userName, '@') & '+test@' & $substringAfter($.userName, '@')}])",
+						"operations.0.create": "true",
+						"operations.0.update": "true",
+						"operations.0.delete": "true",
+					}),
 				),
 			},
 		},
@@ -458,6 +766,70 @@ func TestAccCloudflareAccessApplication_WithSAMLSaas(t *testing.T) {
 	})
 }
 
+func TestAccCloudflareAccessApplication_WithSAMLSaasMultipleCustomAttributes(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithSAMLSaasMultipleCustomAttributes(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_attribute.#", "4"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_attribute.0.name", "rank"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_attribute.1.name", "email"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_attribute.2.name", "department"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_attribute.3.name", "groups"),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithSAMLSaasMultipleCustomAttributes(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithSAMLSaasCustomSSOEndpoint(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithSAMLSaasCustomSSOEndpoint(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "saas_app.0.sso_endpoint", "https://saas-app.example/sso/saml/login"),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithSAMLSaasCustomSSOEndpoint(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
 func TestAccCloudflareAccessApplication_WithSAMLSaas_Import(t *testing.T) {
 	t.Parallel()
 	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
@@ -470,6 +842,7 @@ func TestAccCloudflareAccessApplication_WithSAMLSaas_Import(t *testing.T) {
 		resource.TestCheckResourceAttr(name, "type", "saas"),
 		resource.TestCheckResourceAttr(name, "session_duration", "24h"),
 		resource.TestCheckResourceAttr(name, "saas_app.#", "1"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.auth_type", "saml"),
 		resource.TestCheckResourceAttr(name, "saas_app.0.sp_entity_id", "saas-app.example"),
 		resource.TestCheckResourceAttr(name, "saas_app.0.consumer_service_url", "https://saas-app.example/sso/saml/consume"),
 		resource.TestCheckResourceAttr(name, "saas_app.0.name_id_format", "email"),
@@ -501,10 +874,17 @@ func TestAccCloudflareAccessApplication_WithSAMLSaas_Import(t *testing.T) {
 			{
 				ImportState:         true,
 				ImportStateVerify:   true,
+				ImportStatePersist:  true,
 				ResourceName:        name,
 				ImportStateIdPrefix: fmt.Sprintf("%s/", accountID),
 				Check:               checkFn,
 			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithSAMLSaas(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
 		},
 	})
 }
@@ -532,9 +912,10 @@ func TestAccCloudflareAccessApplication_WithOIDCSaas(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "saas_app.0.auth_type", "oidc"),
 					resource.TestCheckResourceAttr(name, "saas_app.0.redirect_uris.#", "1"),
 					resource.TestCheckResourceAttr(name, "saas_app.0.redirect_uris.0", "https://saas-app.example/sso/oauth2/callback"),
-					resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.#", "2"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.#", "3"),
 					resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.0", "authorization_code"),
 					resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.1", "hybrid"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.2", "refresh_tokens"),
 					resource.TestCheckResourceAttr(name, "saas_app.0.scopes.#", "4"),
 					resource.TestCheckResourceAttr(name, "saas_app.0.scopes.0", "email"),
 					resource.TestCheckResourceAttr(name, "saas_app.0.scopes.1", "groups"),
@@ -555,33 +936,127 @@ func TestAccCloudflareAccessApplication_WithOIDCSaas(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "saas_app.0.hybrid_and_implicit_options.0.return_id_token_from_authorization_endpoint", "true"),
 					resource.TestCheckResourceAttrSet(name, "saas_app.0.client_secret"),
 					resource.TestCheckResourceAttrSet(name, "saas_app.0.public_key"),
+					resource.TestMatchResourceAttr(name, "saas_app.0.oidc_discovery_url", regexp.MustCompile(`^https://.+/cdn-cgi/access/sso/oidc/.+/\.well-known/openid-configuration$`)),
 				),
 			},
 		},
 	})
 }
 
-func TestAccCloudflareAccessApplication_WithOIDCSaas_Import(t *testing.T) {
-	t.Parallel()
-	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+func TestAccCloudflareAccessApplication_WithOIDCSaasOptionalCustomClaim(t *testing.T) {
 	rnd := generateRandomResourceName()
-	name := "cloudflare_zero_trust_access_application." + rnd
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
 
-	checkFn := resource.ComposeTestCheckFunc(
-		resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
-		resource.TestCheckResourceAttr(name, "name", rnd),
-		resource.TestCheckResourceAttr(name, "type", "saas"),
-		resource.TestCheckResourceAttr(name, "session_duration", "24h"),
-		resource.TestCheckResourceAttr(name, "saas_app.#", "1"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.auth_type", "oidc"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.redirect_uris.#", "1"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.redirect_uris.0", "https://saas-app.example/sso/oauth2/callback"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.#", "2"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.0", "authorization_code"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.1", "hybrid"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.scopes.#", "4"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.scopes.0", "email"),
-		resource.TestCheckResourceAttr(name, "saas_app.0.scopes.1", "groups"),
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithOIDCSaasOptionalCustomClaim(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_claim.#", "1"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_claim.0.name", "rank"),
+					resource.TestCheckResourceAttr(name, "saas_app.0.custom_claim.0.required", "false"),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithOIDCSaasOptionalCustomClaim(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithOIDCSaasSAMLOnlyFields(t *testing.T) {
+	rnd := generateRandomResourceName()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithOIDCSaasSAMLOnlyFields(rnd, accountID),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`saas_app.0.name_id_format is only supported when saas_app.0.auth_type is "saml"`)),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithOIDCSaasHybridOptionsWithoutGrant(t *testing.T) {
+	rnd := generateRandomResourceName()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithOIDCSaasHybridOptionsWithoutGrant(rnd, accountID, true),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`saas_app.0.hybrid_and_implicit_options requires saas_app.0.grant_types to include "hybrid" or "implicit"`)),
+			},
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithOIDCSaasHybridOptionsWithoutGrant(rnd, accountID, false),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`saas_app.0.hybrid_and_implicit_options requires saas_app.0.grant_types to include "hybrid" or "implicit"`)),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithOIDCSaasRefreshTokenOptionsWithoutGrant(t *testing.T) {
+	rnd := generateRandomResourceName()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithOIDCSaasRefreshTokenOptionsWithoutGrant(rnd, accountID),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`saas_app.0.refresh_token_options requires saas_app.0.grant_types to include "refresh_tokens"`)),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithOIDCSaas_Import(t *testing.T) {
+	t.Parallel()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	rnd := generateRandomResourceName()
+	name := "cloudflare_zero_trust_access_application." + rnd
+
+	checkFn := resource.ComposeTestCheckFunc(
+		resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+		resource.TestCheckResourceAttr(name, "name", rnd),
+		resource.TestCheckResourceAttr(name, "type", "saas"),
+		resource.TestCheckResourceAttr(name, "session_duration", "24h"),
+		resource.TestCheckResourceAttr(name, "saas_app.#", "1"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.auth_type", "oidc"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.redirect_uris.#", "1"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.redirect_uris.0", "https://saas-app.example/sso/oauth2/callback"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.#", "3"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.0", "authorization_code"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.1", "hybrid"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.grant_types.2", "refresh_tokens"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.scopes.#", "4"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.scopes.0", "email"),
+		resource.TestCheckResourceAttr(name, "saas_app.0.scopes.1", "groups"),
 		resource.TestCheckResourceAttr(name, "saas_app.0.scopes.2", "openid"),
 		resource.TestCheckResourceAttr(name, "saas_app.0.scopes.3", "profile"),
 		resource.TestCheckResourceAttr(name, "saas_app.0.app_launcher_url", "https://saas-app.example/sso/login"),
@@ -598,6 +1073,7 @@ func TestAccCloudflareAccessApplication_WithOIDCSaas_Import(t *testing.T) {
 		resource.TestCheckResourceAttr(name, "saas_app.0.hybrid_and_implicit_options.#", "1"),
 		resource.TestCheckResourceAttr(name, "saas_app.0.hybrid_and_implicit_options.0.return_access_token_from_authorization_endpoint", "true"),
 		resource.TestCheckResourceAttr(name, "saas_app.0.hybrid_and_implicit_options.0.return_id_token_from_authorization_endpoint", "true"),
+		resource.TestCheckResourceAttrSet(name, "saas_app.0.client_id"),
 	)
 
 	resource.Test(t, resource.TestCase{
@@ -615,10 +1091,18 @@ func TestAccCloudflareAccessApplication_WithOIDCSaas_Import(t *testing.T) {
 				ImportState:             true,
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"saas_app.0.client_secret"},
+				ImportStatePersist:      true,
 				ResourceName:            name,
 				ImportStateIdPrefix:     fmt.Sprintf("%s/", accountID),
 				Check:                   checkFn,
 			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithOIDCSaas(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				Check: resource.TestCheckResourceAttrSet(name, "saas_app.0.client_id"),
+			},
 		},
 	})
 }
@@ -650,6 +1134,55 @@ func TestAccCloudflareAccessApplication_WithAutoRedirectToIdentity(t *testing.T)
 	})
 }
 
+func TestAccCloudflareAccessApplication_WithAllIdps(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithAllIdps(rnd, zoneID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "allowed_idps.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "allowed_idps.*", "*"),
+					resource.TestCheckTypeSetElemAttrPair(name, "resolved_allowed_idps.*", fmt.Sprintf("cloudflare_zero_trust_access_identity_provider.%s_otp", rnd), "id"),
+					resource.TestCheckTypeSetElemAttrPair(name, "resolved_allowed_idps.*", fmt.Sprintf("cloudflare_zero_trust_access_identity_provider.%s_github", rnd), "id"),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithAllIdps(rnd, zoneID, domain),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithAutoRedirectToIdentityWithoutIdps(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithAutoRedirectToIdentityWithoutIdps(rnd, zoneID, domain),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("allowed_idps must contain at least one identity provider when auto_redirect_to_identity is enabled")),
+			},
+		},
+	})
+}
+
 func TestAccCloudflareAccessApplication_WithEnableBindingCookie(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
@@ -662,7 +1195,7 @@ func TestAccCloudflareAccessApplication_WithEnableBindingCookie(t *testing.T) {
 		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCloudflareAccessApplicationConfigWithEnableBindingCookie(rnd, zoneID, domain),
+				Config: testAccCloudflareAccessApplicationConfigWithEnableBindingCookie(rnd, zoneID, domain, true),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, consts.ZoneIDSchemaKey, zoneID),
 					resource.TestCheckResourceAttr(name, "name", rnd),
@@ -672,6 +1205,13 @@ func TestAccCloudflareAccessApplication_WithEnableBindingCookie(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "enable_binding_cookie", "true"),
 				),
 			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithEnableBindingCookie(rnd, zoneID, domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "enable_binding_cookie", "false"),
+				),
+			},
 		},
 	})
 }
@@ -695,7 +1235,6 @@ func TestAccCloudflareAccessApplication_WithCustomDenyFields(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "domain", fmt.Sprintf("%s.%s", rnd, domain)),
 					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
 					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
-					resource.TestCheckResourceAttr(name, "custom_deny_message", "denied!"),
 					resource.TestCheckResourceAttr(name, "custom_deny_url", "https://www.cloudflare.com"),
 					resource.TestCheckResourceAttr(name, "custom_non_identity_deny_url", "https://www.blocked.com"),
 				),
@@ -704,6 +1243,58 @@ func TestAccCloudflareAccessApplication_WithCustomDenyFields(t *testing.T) {
 	})
 }
 
+func TestAccCloudflareAccessApplication_WithCustomDenyMessageAndURL(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithCustomDenyMessageAndURL(rnd, zoneID, domain),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("only one of custom_deny_message or custom_deny_url can be configured")),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithEmptyCORSHeaders(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithEmptyCORSHeaders(rnd, zoneID, domain),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("cors_headers must set at least one of allowed_methods, allowed_origins, allowed_headers")),
+			},
+		},
+	})
+}
+
+func TestAccessApplicationCORSHeadersEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, accessApplicationCORSHeadersEmpty(nil))
+	assert.True(t, accessApplicationCORSHeadersEmpty(map[string]interface{}{
+		"allowed_methods":   schema.NewSet(schema.HashString, nil),
+		"allow_all_origins": false,
+		"allow_credentials": true,
+		"max_age":           600,
+	}))
+	assert.False(t, accessApplicationCORSHeadersEmpty(map[string]interface{}{
+		"allowed_origins": schema.NewSet(schema.HashString, []interface{}{"https://example.com"}),
+	}))
+	assert.False(t, accessApplicationCORSHeadersEmpty(map[string]interface{}{
+		"allow_all_headers": true,
+	}))
+}
+
 func TestAccCloudflareAccessApplication_WithADefinedIdps(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
@@ -929,15 +1520,19 @@ func TestAccCloudflareAccessApplication_WithTargetContexts(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "type", "infrastructure"),
 					resource.TestCheckResourceAttr(name, "target_criteria.0.port", "22"),
 					resource.TestCheckResourceAttr(name, "target_criteria.0.protocol", "SSH"),
-					resource.TestCheckResourceAttr(name, "target_criteria.0.target_attributes.0.name", "hostname"),
-					resource.TestCheckResourceAttr(name, "target_criteria.0.target_attributes.0.values.0", "tfgo-acc-test"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "target_criteria.0.target_attributes.*", map[string]string{
+						"name":     "hostname",
+						"values.0": "tfgo-acc-test",
+					}),
 				),
 			},
 		},
 	})
 }
 
-func TestAccCloudflareAccessApplication_WithDestinations(t *testing.T) {
+// The account used for this test requires the infrastructure applications
+// feature to be enabled.
+func TestAccCloudflareAccessApplication_Infrastructure(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
 
@@ -950,48 +1545,63 @@ func TestAccCloudflareAccessApplication_WithDestinations(t *testing.T) {
 		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCloudflareAccessApplicationWithDestinations(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				Config: testAccCloudflareAccessApplicationConfigInfrastructure(rnd, accountID, `"tfgo-acc-test"`),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
-					resource.TestCheckResourceAttr(name, "name", rnd),
-					resource.TestCheckResourceAttr(name, "destinations.#", "2"),
-					resource.TestCheckResourceAttr(name, "destinations.0.type", "public"),
-					resource.TestCheckResourceAttr(name, "destinations.0.uri", fmt.Sprintf("d1.%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "destinations.1.type", "public"),
-					resource.TestCheckResourceAttr(name, "destinations.1.uri", fmt.Sprintf("d2.%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "name", rnd),
-					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
-					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
-					resource.TestCheckResourceAttr(name, "cors_headers.#", "0"),
-					resource.TestCheckResourceAttr(name, "sass_app.#", "0"),
-					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
+					resource.TestCheckResourceAttr(name, "type", "infrastructure"),
+					resource.TestCheckResourceAttr(name, "target_criteria.#", "2"),
+					resource.TestCheckResourceAttr(name, "target_criteria.0.port", "22"),
+					resource.TestCheckResourceAttr(name, "target_criteria.0.protocol", "SSH"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "target_criteria.0.target_attributes.*", map[string]string{
+						"name":     "hostname",
+						"values.#": "1",
+						"values.0": "tfgo-acc-test",
+					}),
+					resource.TestCheckResourceAttr(name, "target_criteria.1.port", "3389"),
+					resource.TestCheckResourceAttr(name, "target_criteria.1.protocol", "RDP"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "target_criteria.1.target_attributes.*", map[string]string{
+						"name":     "hostname",
+						"values.#": "1",
+						"values.0": "tfgo-acc-test",
+					}),
 				),
 			},
 			{
-				Config: testAccCloudflareAccessApplicationWithDestinations2(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				// domain and session_duration don't apply to infrastructure
+				// applications and must not cause a diff.
+				Config: testAccCloudflareAccessApplicationConfigInfrastructure(rnd, accountID, `"tfgo-acc-test"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigInfrastructure(rnd, accountID, `"tfgo-acc-test", "tfgo-acc-test-2"`),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
-					resource.TestCheckResourceAttr(name, "name", rnd),
-					resource.TestCheckResourceAttr(name, "destinations.#", "2"),
-					resource.TestCheckResourceAttr(name, "destinations.0.type", "public"),
-					resource.TestCheckResourceAttr(name, "destinations.0.uri", fmt.Sprintf("d3.%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "destinations.1.type", "public"),
-					resource.TestCheckResourceAttr(name, "destinations.1.uri", fmt.Sprintf("d4.%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "name", rnd),
-					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
-					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
-					resource.TestCheckResourceAttr(name, "cors_headers.#", "0"),
-					resource.TestCheckResourceAttr(name, "sass_app.#", "0"),
-					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "target_criteria.0.target_attributes.*", map[string]string{
+						"name":     "hostname",
+						"values.#": "2",
+						"values.1": "tfgo-acc-test-2",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "target_criteria.1.target_attributes.*", map[string]string{
+						"name":     "hostname",
+						"values.#": "2",
+						"values.1": "tfgo-acc-test-2",
+					}),
 				),
 			},
+			{
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ResourceName:            name,
+				ImportStateIdPrefix:     fmt.Sprintf("account/%s/", accountID),
+				ImportStateVerifyIgnore: []string{"session_duration"},
+			},
 		},
 	})
 }
 
-func TestAccCloudflareAccessApplication_WithSelfHostedDomains(t *testing.T) {
+func TestAccCloudflareAccessApplication_WithTargetContextsEmptyValues(t *testing.T) {
 	rnd := generateRandomResourceName()
-	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -999,47 +1609,19 @@ func TestAccCloudflareAccessApplication_WithSelfHostedDomains(t *testing.T) {
 			testAccPreCheckAccount(t)
 		},
 		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCloudflareAccessApplicationWithSelfHostedDomains(rnd, domain, cloudflare.AccountIdentifier(accountID)),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
-					resource.TestCheckResourceAttr(name, "name", rnd),
-					resource.TestCheckResourceAttr(name, "self_hosted_domains.#", "2"),
-					resource.TestCheckResourceAttr(name, "self_hosted_domains.0", fmt.Sprintf("d1.%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "self_hosted_domains.1", fmt.Sprintf("d2.%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "name", rnd),
-					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
-					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
-					resource.TestCheckResourceAttr(name, "cors_headers.#", "0"),
-					resource.TestCheckResourceAttr(name, "sass_app.#", "0"),
-					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
-				),
-			},
-			{
-				Config: testAccCloudflareAccessApplicationWithSelfHostedDomains2(rnd, domain, cloudflare.AccountIdentifier(accountID)),
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
-					resource.TestCheckResourceAttr(name, "name", rnd),
-					resource.TestCheckResourceAttr(name, "self_hosted_domains.#", "2"),
-					resource.TestCheckResourceAttr(name, "self_hosted_domains.0", fmt.Sprintf("d3.%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "self_hosted_domains.1", fmt.Sprintf("d4.%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
-					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
-					resource.TestCheckResourceAttr(name, "cors_headers.#", "0"),
-					resource.TestCheckResourceAttr(name, "sass_app.#", "0"),
-					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
-				),
+				Config:      testAccCloudflareAccessApplicationWithTargetContextsEmptyValues(rnd, cloudflare.AccountIdentifier(accountID)),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("requires 1 item minimum, but config has only 0 declared")),
 			},
 		},
 	})
 }
 
-func TestAccCloudflareAccessApplication_WithDefinedTags(t *testing.T) {
+func TestAccCloudflareAccessApplication_BookmarkApp(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
-	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
@@ -1049,35 +1631,323 @@ func TestAccCloudflareAccessApplication_WithDefinedTags(t *testing.T) {
 		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCloudflareAccessApplicationConfigWithADefinedTag(rnd, zoneID, domain, accountID),
+				Config: testAccCloudflareAccessApplicationConfigBookmarkApp(rnd, domain, accountID, ""),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(name, consts.ZoneIDSchemaKey, zoneID),
-					resource.TestCheckResourceAttr(name, "name", rnd),
-					resource.TestCheckResourceAttr(name, "domain", fmt.Sprintf("%s.%s", rnd, domain)),
-					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
-					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
-					resource.TestCheckResourceAttr(name, "tags.#", "1"),
+					resource.TestCheckResourceAttr(name, "type", "bookmark"),
+					resource.TestCheckResourceAttr(name, "bookmark_app.#", "1"),
+					resource.TestCheckResourceAttr(name, "bookmark_app.0.url", fmt.Sprintf("https://%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "bookmark_app.0.logo_url", "https://www.cloudflare.com/img/logo-web-badges/cf-logo-on-white-bg.svg"),
 				),
 			},
+			{
+				Config: testAccCloudflareAccessApplicationConfigBookmarkApp(rnd, domain, accountID, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				Config:      testAccCloudflareAccessApplicationConfigBookmarkApp(rnd, domain, accountID, fmt.Sprintf("domain = \"%s.%s\"", rnd, domain)),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta("domain cannot be set together with bookmark_app")),
+			},
 		},
 	})
 }
 
-func TestAccCloudflareAccessApplication_WithReusablePolicies(t *testing.T) {
+func TestAccCloudflareAccessApplication_WithoutDomain(t *testing.T) {
 	rnd := generateRandomResourceName()
-	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
-	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
 			testAccPreCheck(t)
 			testAccPreCheckAccount(t)
 		},
 		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccCloudflareAccessApplicationConfigWithReusablePolicies(rnd, domain, accountID),
-				Check: resource.ComposeTestCheckFunc(
+				Config:      testAccCloudflareAccessApplicationConfigWithoutDomain(rnd, accountID, "self_hosted"),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`domain, destinations or self_hosted_domains must be configured for "self_hosted" applications`)),
+			},
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithoutDomain(rnd, accountID, "ssh"),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`domain, destinations or self_hosted_domains must be configured for "ssh" applications`)),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithDuplicatePolicies(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithDuplicatePolicies(rnd, accountID, domain),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`policies must not contain duplicates, found: 00000000-0000-0000-0000-000000000001`)),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithDestinations(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationWithDestinations(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "destinations.#", "2"),
+					resource.TestCheckResourceAttr(name, "destinations.0.type", "public"),
+					resource.TestCheckResourceAttr(name, "destinations.0.uri", fmt.Sprintf("d1.%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "destinations.1.type", "public"),
+					resource.TestCheckResourceAttr(name, "destinations.1.uri", fmt.Sprintf("d2.%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
+					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
+					resource.TestCheckResourceAttr(name, "cors_headers.#", "0"),
+					resource.TestCheckResourceAttr(name, "sass_app.#", "0"),
+					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationWithDestinations(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: testAccCloudflareAccessApplicationWithDestinations2(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "destinations.#", "2"),
+					resource.TestCheckResourceAttr(name, "destinations.0.type", "public"),
+					resource.TestCheckResourceAttr(name, "destinations.0.uri", fmt.Sprintf("d3.%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "destinations.1.type", "public"),
+					resource.TestCheckResourceAttr(name, "destinations.1.uri", fmt.Sprintf("d4.%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
+					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
+					resource.TestCheckResourceAttr(name, "cors_headers.#", "0"),
+					resource.TestCheckResourceAttr(name, "sass_app.#", "0"),
+					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestConvertDestinationsToStructDefaultsTypeToPublic(t *testing.T) {
+	destinations, err := convertDestinationsToStruct([]interface{}{
+		map[string]interface{}{"uri": "d1.example.com"},
+		map[string]interface{}{"type": "", "uri": "d2.example.com"},
+		map[string]interface{}{"type": "private", "uri": "10.0.0.1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []cloudflare.AccessDestination{
+		{Type: cloudflare.AccessDestinationPublic, URI: "d1.example.com"},
+		{Type: cloudflare.AccessDestinationPublic, URI: "d2.example.com"},
+		{Type: cloudflare.AccessDestinationPrivate, URI: "10.0.0.1"},
+	}, destinations)
+}
+
+func TestAccCloudflareAccessApplication_WithSelfHostedDomains(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationWithSelfHostedDomains(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "self_hosted_domains.#", "2"),
+					resource.TestCheckResourceAttr(name, "self_hosted_domains.0", fmt.Sprintf("d1.%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "self_hosted_domains.1", fmt.Sprintf("d2.%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
+					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
+					resource.TestCheckResourceAttr(name, "cors_headers.#", "0"),
+					resource.TestCheckResourceAttr(name, "sass_app.#", "0"),
+					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
+				),
+			},
+			{
+				Config: testAccCloudflareAccessApplicationWithSelfHostedDomains2(rnd, domain, cloudflare.AccountIdentifier(accountID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "self_hosted_domains.#", "2"),
+					resource.TestCheckResourceAttr(name, "self_hosted_domains.0", fmt.Sprintf("d3.%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "self_hosted_domains.1", fmt.Sprintf("d4.%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
+					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
+					resource.TestCheckResourceAttr(name, "cors_headers.#", "0"),
+					resource.TestCheckResourceAttr(name, "sass_app.#", "0"),
+					resource.TestCheckResourceAttr(name, "auto_redirect_to_identity", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithDefinedTags(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithADefinedTag(rnd, zoneID, domain, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.ZoneIDSchemaKey, zoneID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "domain", fmt.Sprintf("%s.%s", rnd, domain)),
+					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
+					resource.TestCheckResourceAttr(name, "session_duration", "24h"),
+					resource.TestCheckResourceAttr(name, "tags.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareAccessApplication_WithMissingTagCreated(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckCloudflareAccessApplicationDestroy,
+			func(s *terraform.State) error {
+				client := testAccProvider.Meta().(*cloudflare.API)
+				return client.DeleteAccessTag(context.Background(), cloudflare.AccountIdentifier(accountID), rnd)
+			},
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithMissingTag(rnd, accountID, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "create_missing_tags", "true"),
+					resource.TestCheckResourceAttr(name, "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "tags.*", rnd),
+					func(s *terraform.State) error {
+						client := testAccProvider.Meta().(*cloudflare.API)
+						_, err := client.GetAccessTag(context.Background(), cloudflare.AccountIdentifier(accountID), rnd)
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestCreateMissingAccessTags(t *testing.T) {
+	t.Parallel()
+
+	var created []string
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			var body cloudflare.CreateAccessTagParams
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			created = append(created, body.Name)
+			fmt.Fprintf(w, `{"success": true, "errors": [], "messages": [], "result": {"name": %q}}`, body.Name)
+			return
+		}
+
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [{"name": "engineers", "app_count": 2}],
+			"result_info": {"page": 1, "per_page": 20, "count": 1, "total_count": 1}
+		}`)
+	})
+
+	err := createMissingAccessTags(context.Background(), client, cloudflare.AccountIdentifier("identifier"), []string{"engineers", "contractors"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"contractors"}, created)
+}
+
+func TestResolveAccessApplicationAllowedIdps(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{"id": "f174e90a-fafe-4643-bbbc-4a0ed4fc8415", "name": "OTP", "type": "onetimepin"},
+				{"id": "0a6f3a2b-4c7e-4d36-9f0a-8f4b1f5d2c11", "name": "GitHub", "type": "github"}
+			],
+			"result_info": {"page": 1, "per_page": 20, "count": 2, "total_count": 2}
+		}`)
+	})
+
+	idps, err := resolveAccessApplicationAllowedIdps(context.Background(), client, cloudflare.AccountIdentifier("identifier"), []string{"*"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"f174e90a-fafe-4643-bbbc-4a0ed4fc8415", "0a6f3a2b-4c7e-4d36-9f0a-8f4b1f5d2c11"}, idps)
+
+	idps, err = resolveAccessApplicationAllowedIdps(context.Background(), client, cloudflare.AccountIdentifier("identifier"), []string{"f174e90a-fafe-4643-bbbc-4a0ed4fc8415"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"f174e90a-fafe-4643-bbbc-4a0ed4fc8415"}, idps)
+	assert.Equal(t, 1, requests)
+}
+
+func TestAccCloudflareAccessApplication_WithReusablePolicies(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareAccessApplicationConfigWithReusablePolicies(rnd, domain, accountID),
+				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", rnd),
 					resource.TestCheckResourceAttr(name, "domain", fmt.Sprintf("%s.%s", rnd, domain)),
 					resource.TestCheckResourceAttr(name, "type", "self_hosted"),
@@ -1125,6 +1995,41 @@ func TestAccCloudflareAccessApplication_WithAppLauncherCustomization(t *testing.
 	})
 }
 
+func TestAccCloudflareAccessApplication_AppLauncherImport(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccessApplicationWithAppLauncherCustomizationFields(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+					resource.TestCheckResourceAttr(name, "type", "app_launcher"),
+				),
+			},
+			{
+				ImportState:         true,
+				ImportStatePersist:  true,
+				ResourceName:        name,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", accountID),
+			},
+			{
+				Config: testAccessApplicationWithAppLauncherCustomizationFields(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
 func TestAccCloudflareAccessApplication_AuthTypeForcesNewResource(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := fmt.Sprintf("cloudflare_zero_trust_access_application.%s", rnd)
@@ -1140,7 +2045,7 @@ func TestAccCloudflareAccessApplication_AuthTypeForcesNewResource(t *testing.T)
 			{
 				Config: testAccCloudflareAccessApplicationConfigWithSAMLSaas(rnd, accountID),
 				Check: resource.ComposeTestCheckFunc(
-					resource.TestCheckResourceAttr(name, "saas_app.0.auth_type", ""),
+					resource.TestCheckResourceAttr(name, "saas_app.0.auth_type", "saml"),
 				),
 			},
 			{
@@ -1153,6 +2058,25 @@ func TestAccCloudflareAccessApplication_AuthTypeForcesNewResource(t *testing.T)
 	})
 }
 
+func TestAccCloudflareAccessApplication_WithOIDCSaasMissingRefreshTokenOptions(t *testing.T) {
+	rnd := generateRandomResourceName()
+	accountID := os.Getenv("CLOUDFLARE_ACCOUNT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckAccount(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareAccessApplicationConfigWithOIDCSaasMissingRefreshTokenOptions(rnd, accountID),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`saas_app.0.refresh_token_options must be configured when saas_app.0.grant_types includes "refresh_tokens"`)),
+			},
+		},
+	})
+}
+
 func testAccCloudflareAccessApplicationConfigBasic(rnd string, domain string, identifier *cloudflare.ResourceContainer) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_application" "%[1]s" {
@@ -1221,7 +2145,7 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
 `, rnd, accountID)
 }
 
-func testAccCloudflareAccessApplicationConfigWithOIDCSaas(rnd, accountID string) string {
+func testAccCloudflareAccessApplicationConfigWithSAMLSaasMultipleCustomAttributes(rnd, accountID string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_application" "%[1]s" {
   account_id       = "%[2]s"
@@ -1229,14 +2153,73 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
   type             = "saas"
   session_duration = "24h"
   saas_app {
-	auth_type = "oidc"
-	redirect_uris = ["https://saas-app.example/sso/oauth2/callback"]
-	grant_types = ["authorization_code", "hybrid"]
-	scopes = ["openid", "email", "profile", "groups"]
-	app_launcher_url = "https://saas-app.example/sso/login"
-	group_filter_regex = ".*"
-	access_token_lifetime = "5m"
-	allow_pkce_without_client_secret = false
+    consumer_service_url = "https://saas-app.example/sso/saml/consume"
+    sp_entity_id         = "saas-app.example"
+    name_id_format       = "email"
+
+    custom_attribute {
+      name = "rank"
+      source {
+        name = "rank"
+      }
+    }
+    custom_attribute {
+      name        = "email"
+      name_format = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+      source {
+        name = "user_email"
+      }
+    }
+    custom_attribute {
+      name = "department"
+      source {
+        name = "department"
+      }
+    }
+    custom_attribute {
+      name = "groups"
+      source {
+        name = "groups"
+      }
+    }
+  }
+}
+`, rnd, accountID)
+}
+
+func testAccCloudflareAccessApplicationConfigWithSAMLSaasCustomSSOEndpoint(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "saas"
+  session_duration = "24h"
+  saas_app {
+    consumer_service_url = "https://saas-app.example/sso/saml/consume"
+    sp_entity_id         = "saas-app.example"
+    name_id_format       = "email"
+    sso_endpoint         = "https://saas-app.example/sso/saml/login"
+  }
+}
+`, rnd, accountID)
+}
+
+func testAccCloudflareAccessApplicationConfigWithOIDCSaas(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "saas"
+  session_duration = "24h"
+  saas_app {
+	auth_type = "oidc"
+	redirect_uris = ["https://saas-app.example/sso/oauth2/callback"]
+	grant_types = ["authorization_code", "hybrid", "refresh_tokens"]
+	scopes = ["openid", "email", "profile", "groups"]
+	app_launcher_url = "https://saas-app.example/sso/login"
+	group_filter_regex = ".*"
+	access_token_lifetime = "5m"
+	allow_pkce_without_client_secret = false
 	refresh_token_options {
 		lifetime = "1h"
 	}
@@ -1259,6 +2242,105 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
 `, rnd, accountID)
 }
 
+func testAccCloudflareAccessApplicationConfigWithOIDCSaasOptionalCustomClaim(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "saas"
+  session_duration = "24h"
+  saas_app {
+	auth_type = "oidc"
+	redirect_uris = ["https://saas-app.example/sso/oauth2/callback"]
+	grant_types = ["authorization_code"]
+	scopes = ["openid", "email", "profile"]
+	custom_claim {
+		name = "rank"
+		scope = "profile"
+		source {
+			name = "rank"
+		}
+	}
+  }
+}
+`, rnd, accountID)
+}
+
+func testAccCloudflareAccessApplicationConfigWithOIDCSaasSAMLOnlyFields(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "saas"
+  session_duration = "24h"
+  saas_app {
+	auth_type = "oidc"
+	redirect_uris = ["https://saas-app.example/sso/oauth2/callback"]
+	grant_types = ["authorization_code"]
+	scopes = ["openid", "email", "profile"]
+	name_id_format = "email"
+  }
+}
+`, rnd, accountID)
+}
+
+func testAccCloudflareAccessApplicationConfigWithOIDCSaasHybridOptionsWithoutGrant(rnd, accountID string, returnIDToken bool) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "saas"
+  session_duration = "24h"
+  saas_app {
+	auth_type = "oidc"
+	redirect_uris = ["https://saas-app.example/sso/oauth2/callback"]
+	grant_types = ["authorization_code"]
+	scopes = ["openid", "email", "profile"]
+	hybrid_and_implicit_options {
+		return_id_token_from_authorization_endpoint = %[3]t
+	}
+  }
+}
+`, rnd, accountID, returnIDToken)
+}
+
+func testAccCloudflareAccessApplicationConfigWithOIDCSaasRefreshTokenOptionsWithoutGrant(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "saas"
+  session_duration = "24h"
+  saas_app {
+	auth_type = "oidc"
+	redirect_uris = ["https://saas-app.example/sso/oauth2/callback"]
+	grant_types = ["authorization_code"]
+	scopes = ["openid", "email", "profile"]
+	refresh_token_options {
+		lifetime = "1h"
+	}
+  }
+}
+`, rnd, accountID)
+}
+
+func testAccCloudflareAccessApplicationConfigWithOIDCSaasMissingRefreshTokenOptions(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "saas"
+  session_duration = "24h"
+  saas_app {
+	auth_type = "oidc"
+	redirect_uris = ["https://saas-app.example/sso/oauth2/callback"]
+	grant_types = ["authorization_code", "refresh_tokens"]
+	scopes = ["openid", "email", "profile", "groups"]
+  }
+}
+`, rnd, accountID)
+}
+
 func testAccCloudflareAccessApplicationConfigWithAutoRedirectToIdentity(rnd, zoneID, domain string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_identity_provider" "%[1]s" {
@@ -1281,19 +2363,65 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
 `, rnd, zoneID, domain)
 }
 
-func testAccCloudflareAccessApplicationConfigWithEnableBindingCookie(rnd, zoneID, domain string) string {
+func testAccCloudflareAccessApplicationConfigWithAllIdps(rnd, zoneID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_identity_provider" "%[1]s_otp" {
+  zone_id = "%[2]s"
+  name    = "%[1]s-otp"
+  type    = "onetimepin"
+}
+
+resource "cloudflare_zero_trust_access_identity_provider" "%[1]s_github" {
+  zone_id = "%[2]s"
+  name    = "%[1]s-github"
+  type    = "github"
+  config {
+    client_id     = "test"
+    client_secret = "secret"
+  }
+}
+
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  zone_id          = "%[2]s"
+  name             = "%[1]s"
+  domain           = "%[1]s.%[3]s"
+  type             = "self_hosted"
+  session_duration = "24h"
+  allowed_idps     = ["*"]
+
+  depends_on = [
+    cloudflare_zero_trust_access_identity_provider.%[1]s_otp,
+    cloudflare_zero_trust_access_identity_provider.%[1]s_github,
+  ]
+}
+`, rnd, zoneID, domain)
+}
+
+func testAccCloudflareAccessApplicationConfigWithAutoRedirectToIdentityWithoutIdps(rnd, zoneID, domain string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_application" "%[1]s" {
   zone_id                   = "%[2]s"
   name                      = "%[1]s"
   domain                    = "%[1]s.%[3]s"
   type                      = "self_hosted"
-  session_duration          = "24h"
-  enable_binding_cookie     = true
+  auto_redirect_to_identity = true
 }
 `, rnd, zoneID, domain)
 }
 
+func testAccCloudflareAccessApplicationConfigWithEnableBindingCookie(rnd, zoneID, domain string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  zone_id                   = "%[2]s"
+  name                      = "%[1]s"
+  domain                    = "%[1]s.%[3]s"
+  type                      = "self_hosted"
+  session_duration          = "24h"
+  enable_binding_cookie     = %[4]t
+}
+`, rnd, zoneID, domain, enabled)
+}
+
 func testAccCloudflareAccessApplicationConfigWithCustomDenyFields(rnd, zoneID, domain string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_application" "%[1]s" {
@@ -1302,13 +2430,38 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
   domain                    = "%[1]s.%[3]s"
   type                      = "self_hosted"
   session_duration          = "24h"
-  custom_deny_message       = "denied!"
   custom_deny_url           = "https://www.cloudflare.com"
 	custom_non_identity_deny_url = "https://www.blocked.com"
 }
 `, rnd, zoneID, domain)
 }
 
+func testAccCloudflareAccessApplicationConfigWithCustomDenyMessageAndURL(rnd, zoneID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  zone_id             = "%[2]s"
+  name                = "%[1]s"
+  domain              = "%[1]s.%[3]s"
+  type                = "self_hosted"
+  custom_deny_message = "denied!"
+  custom_deny_url     = "https://www.cloudflare.com"
+}
+`, rnd, zoneID, domain)
+}
+
+func testAccCloudflareAccessApplicationConfigWithEmptyCORSHeaders(rnd, zoneID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  zone_id = "%[2]s"
+  name    = "%[1]s"
+  domain  = "%[1]s.%[3]s"
+  type    = "self_hosted"
+
+  cors_headers {}
+}
+`, rnd, zoneID, domain)
+}
+
 func testAccCloudflareAccessApplicationConfigWithADefinedIdp(rnd, zoneID, domain string, accountID string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_identity_provider" "%[1]s" {
@@ -1484,6 +2637,96 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
 `, rnd, domain, identifier.Type, identifier.Identifier)
 }
 
+func testAccCloudflareAccessApplicationConfigInfrastructure(rnd, accountID, hostnames string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id = "%[2]s"
+  name       = "%[1]s"
+  type       = "infrastructure"
+
+  target_criteria {
+    port     = 22
+    protocol = "SSH"
+    target_attributes {
+      name   = "hostname"
+      values = [%[3]s]
+    }
+  }
+
+  target_criteria {
+    port     = 3389
+    protocol = "RDP"
+    target_attributes {
+      name   = "hostname"
+      values = [%[3]s]
+    }
+  }
+}
+`, rnd, accountID, hostnames)
+}
+
+func testAccCloudflareAccessApplicationWithTargetContextsEmptyValues(rnd string, identifier *cloudflare.ResourceContainer) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  %[2]s_id                  = "%[3]s"
+  name                      = "%[1]s"
+  type                      = "infrastructure"
+  target_criteria {
+    port     = 22
+    protocol = "SSH"
+    target_attributes {
+      name = "hostname"
+      values = []
+    }
+  }
+}
+`, rnd, identifier.Type, identifier.Identifier)
+}
+
+func testAccCloudflareAccessApplicationConfigBookmarkApp(rnd, domain, accountID, extra string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id = "%[3]s"
+  name       = "%[1]s"
+  type       = "bookmark"
+  %[4]s
+
+  bookmark_app {
+    url      = "https://%[1]s.%[2]s"
+    logo_url = "https://www.cloudflare.com/img/logo-web-badges/cf-logo-on-white-bg.svg"
+  }
+}
+`, rnd, domain, accountID, extra)
+}
+
+func testAccCloudflareAccessApplicationConfigWithDuplicatePolicies(rnd, accountID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  domain           = "%[1]s.%[3]s"
+  type             = "self_hosted"
+  session_duration = "24h"
+  policies = [
+    "00000000-0000-0000-0000-000000000001",
+    "00000000-0000-0000-0000-000000000002",
+    "00000000-0000-0000-0000-000000000001",
+  ]
+}
+`, rnd, accountID, domain)
+}
+
+func testAccCloudflareAccessApplicationConfigWithoutDomain(rnd, accountID, appType string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "%[3]s"
+  session_duration = "24h"
+}
+`, rnd, accountID, appType)
+}
+
 func testAccCloudflareAccessApplicationWithDestinations(rnd string, domain string, identifier *cloudflare.ResourceContainer) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_application" "%[1]s" {
@@ -1827,6 +3070,20 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
 `, rnd, zoneID, domain, accountID)
 }
 
+func testAccCloudflareAccessApplicationConfigWithMissingTag(rnd, accountID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id                = "%[2]s"
+  name                      = "%[1]s"
+  domain                    = "%[1]s.%[3]s"
+  type                      = "self_hosted"
+  session_duration          = "24h"
+  tags                      = ["%[1]s"]
+  create_missing_tags       = true
+}
+`, rnd, accountID, domain)
+}
+
 func testAccCloudflareAccessApplicationSCIMConfigValidHttpBasic(rnd, accountID, domain string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_identity_provider" "%[1]s" {
@@ -1855,7 +3112,7 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
   domain = "%[1]s.%[3]s"
   scim_config {
 	enabled = true
-	remote_uri = "scim.com"
+	remote_uri = "https://scim.com"
 	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
 	deactivate_on_delete = true
 	authentication {
@@ -1880,7 +3137,27 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
 `, rnd, accountID, domain)
 }
 
-func testAccCloudflareAccessApplicationSCIMConfigValidOAuthBearerTokenNoMappings(rnd, accountID, domain string) string {
+func testAccCloudflareAccessApplicationSCIMConfigMultipleMappings(rnd, accountID, domain string, reversed bool) string {
+	userMapping := `
+	mappings {
+		schema = "urn:ietf:params:scim:schemas:core:2.0:User"
+		enabled = true
+		filter = "title pr or userType eq \"Intern\""
+		strictness = "passthrough"
+	}`
+	groupMapping := `
+	mappings {
+		schema = "urn:ietf:params:scim:schemas:core:2.0:Group"
+		enabled = true
+		filter = "displayName sw \"Engineering\""
+		strictness = "strict"
+	}`
+
+	mappings := userMapping + groupMapping
+	if reversed {
+		mappings = groupMapping + userMapping
+	}
+
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_access_identity_provider" "%[1]s" {
 	account_id = "%[2]s"
@@ -1907,8 +3184,192 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
   session_duration = "24h"
   domain = "%[1]s.%[3]s"
   scim_config {
-	enabled = false
-	remote_uri = "scim2.com"
+	enabled = true
+	remote_uri = "https://scim.com"
+	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
+	deactivate_on_delete = true
+	authentication {
+		scheme =  "httpbasic"
+		user = "test"
+		password = "12345"
+	}
+	%[4]s
+  }
+}
+`, rnd, accountID, domain, mappings)
+}
+
+func testAccCloudflareAccessApplicationSCIMConfigWithoutDeactivateOnDelete(rnd, accountID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_identity_provider" "%[1]s" {
+	account_id = "%[2]s"
+	name       = "%[1]s"
+	type       = "azureAD"
+	config {
+		client_id      = "test"
+		client_secret  = "test"
+		directory_id   = "directory"
+		support_groups = true
+	}
+	scim_config {
+		enabled                  = true
+		group_member_deprovision = true
+		seat_deprovision         = true
+		user_deprovision         = true
+	}
+}
+
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "self_hosted"
+  session_duration = "24h"
+  domain = "%[1]s.%[3]s"
+  scim_config {
+	enabled = true
+	remote_uri = "https://scim.com"
+	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
+	authentication {
+		scheme =  "httpbasic"
+		user = "test"
+		password = "12345"
+	}
+  }
+}
+`, rnd, accountID, domain)
+}
+
+func testAccCloudflareAccessApplicationSCIMConfigMappingWithoutEnabled(rnd, accountID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_identity_provider" "%[1]s" {
+	account_id = "%[2]s"
+	name       = "%[1]s"
+	type       = "azureAD"
+	config {
+		client_id      = "test"
+		client_secret  = "test"
+		directory_id   = "directory"
+		support_groups = true
+	}
+	scim_config {
+		enabled                  = true
+		group_member_deprovision = true
+		seat_deprovision         = true
+		user_deprovision         = true
+	}
+}
+
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "self_hosted"
+  session_duration = "24h"
+  domain = "%[1]s.%[3]s"
+  scim_config {
+	enabled = true
+	remote_uri = "https://scim.com"
+	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
+	deactivate_on_delete = true
+	authentication {
+		scheme =  "httpbasic"
+		user = "test"
+		password = "12345"
+	}
+	mappings {
+		schema = "urn:ietf:params:scim:schemas:core:2.0:User"
+		filter = "title pr or userType eq \"Intern\""
+	}
+  }
+}
+`, rnd, accountID, domain)
+}
+
+func testAccCloudflareAccessApplicationSCIMConfigWithoutAuthentication(rnd, accountID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "self_hosted"
+  session_duration = "24h"
+  domain           = "%[1]s.%[3]s"
+  scim_config {
+    enabled    = true
+    remote_uri = "https://scim.com"
+    idp_uid    = "00000000-0000-0000-0000-000000000000"
+  }
+}
+`, rnd, accountID, domain)
+}
+
+func testAccCloudflareAccessApplicationSCIMConfigWithoutEnabled(rnd, accountID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_identity_provider" "%[1]s" {
+	account_id = "%[2]s"
+	name       = "%[1]s"
+	type       = "azureAD"
+	config {
+		client_id      = "test"
+		client_secret  = "test"
+		directory_id   = "directory"
+		support_groups = true
+	}
+	scim_config {
+		enabled                  = true
+		group_member_deprovision = true
+		seat_deprovision         = true
+		user_deprovision         = true
+	}
+}
+
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "self_hosted"
+  session_duration = "24h"
+  domain = "%[1]s.%[3]s"
+  scim_config {
+	remote_uri = "https://scim.com"
+	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
+	deactivate_on_delete = true
+	authentication {
+		scheme =  "httpbasic"
+		user = "test"
+		password = "12345"
+	}
+  }
+}
+`, rnd, accountID, domain)
+}
+
+func testAccCloudflareAccessApplicationSCIMConfigValidOAuthBearerTokenNoMappings(rnd, accountID, domain string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_access_identity_provider" "%[1]s" {
+	account_id = "%[2]s"
+	name       = "%[1]s"
+	type       = "azureAD"
+	config {
+		client_id      = "test"
+		client_secret  = "test"
+		directory_id   = "directory"
+		support_groups = true
+	}
+	scim_config {
+		enabled                  = true
+		group_member_deprovision = true
+		seat_deprovision         = true
+		user_deprovision         = true
+	}
+}
+
+resource "cloudflare_zero_trust_access_application" "%[1]s" {
+  account_id       = "%[2]s"
+  name             = "%[1]s"
+  type             = "self_hosted"
+  session_duration = "24h"
+  domain = "%[1]s.%[3]s"
+  scim_config {
+	enabled = false
+	remote_uri = "https://scim2.com"
 	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
 	deactivate_on_delete = false
 	authentication {
@@ -1948,7 +3409,7 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
   domain = "%[1]s.%[3]s"
   scim_config {
 	enabled = true
-	remote_uri = "scim.com"
+	remote_uri = "https://scim.com"
 	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
 	deactivate_on_delete = true
 	authentication {
@@ -2000,7 +3461,7 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
   domain = "%[1]s.%[3]s"
   scim_config {
 	enabled = true
-	remote_uri = "scim.com"
+	remote_uri = "https://scim.com"
 	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
 	deactivate_on_delete = true
 	authentication {
@@ -2057,7 +3518,7 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
   domain = "%[1]s.%[3]s"
   scim_config {
 	enabled = true
-	remote_uri = "scim.com"
+	remote_uri = "https://scim.com"
 	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
 	deactivate_on_delete = true
 	authentication {
@@ -2112,7 +3573,7 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
   domain = "%[1]s.%[3]s"
   scim_config {
 	enabled = true
-	remote_uri = "scim.com"
+	remote_uri = "https://scim.com"
 	idp_uid = cloudflare_zero_trust_access_identity_provider.%[1]s.id
 	deactivate_on_delete = true
 	authentication {
@@ -2168,3 +3629,1018 @@ resource "cloudflare_zero_trust_access_application" "%[1]s" {
 }
 `, rnd, domain, accountID)
 }
+
+func TestSuppressEquivalentDurations(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		{"24h", "24h", true},
+		{"24h0m0s", "24h", true},
+		{"2h45m0s", "2h45m", true},
+		{"24h0m0s", "2h45m", false},
+		{"", "24h", false},
+		{"invalid", "24h", false},
+		{"30m0s", "30m", true},
+		{"10m0s", "1h", false},
+	}
+
+	for _, c := range cases {
+		got := suppressEquivalentDurations("session_duration", c.old, c.new, nil)
+		assert.Equal(t, c.expected, got)
+	}
+}
+
+func TestValidateCORSMaxAge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value        int
+		expectWarns  int
+		expectErrors int
+	}{
+		{-1, 0, 0},
+		{0, 1, 0},
+		{600, 0, 0},
+		{86400, 0, 0},
+		{-2, 0, 1},
+		{86401, 0, 1},
+	}
+
+	for _, c := range cases {
+		warns, errs := validateCORSMaxAge(c.value, "cors_headers.0.max_age")
+		assert.Len(t, warns, c.expectWarns, "max_age %d", c.value)
+		assert.Len(t, errs, c.expectErrors, "max_age %d", c.value)
+	}
+}
+
+func TestValidateCORSAllowedOrigin(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]bool{
+		"*":                          true,
+		"https://example.com":        true,
+		"http://example.com:8080":    true,
+		"https://app.example.com/":   true,
+		"https://*.example.com":      true,
+		"example.com":                false,
+		"*.example.com":              false,
+		"ftp://example.com":          false,
+		"https://":                   false,
+		"https://*.*.example.com":    false,
+		"https://app.*.example.com":  false,
+		"https://*.com":              false,
+		"https://example.com/path":   false,
+		"https://example.com?q=1":    false,
+		"https://user@example.com":   false,
+		"https://example.com#anchor": false,
+	}
+
+	for origin, valid := range cases {
+		_, errs := validateCORSAllowedOrigin(origin, "cors_headers.0.allowed_origins")
+		if valid {
+			assert.Empty(t, errs, "origin %q", origin)
+		} else {
+			assert.Len(t, errs, 1, "origin %q", origin)
+		}
+	}
+}
+
+func TestAccessApplicationCORSAllowedMethodsValidation(t *testing.T) {
+	t.Parallel()
+
+	corsHeaders := resourceCloudflareAccessApplicationSchema()["cors_headers"].Elem.(*schema.Resource)
+	validateFn := corsHeaders.Schema["allowed_methods"].Elem.(*schema.Schema).ValidateFunc
+
+	for _, value := range []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS", "HEAD"} {
+		_, errs := validateFn(value, "allowed_methods")
+		assert.Empty(t, errs, "allowed_methods %q", value)
+	}
+
+	for _, value := range []string{"FETCH", "get", ""} {
+		_, errs := validateFn(value, "allowed_methods")
+		assert.NotEmpty(t, errs, "allowed_methods %q", value)
+	}
+}
+
+func TestAccessApplicationSCIMMappingStrictnessValidation(t *testing.T) {
+	t.Parallel()
+
+	scimConfig := resourceCloudflareAccessApplicationSchema()["scim_config"].Elem.(*schema.Resource)
+	mappings := scimConfig.Schema["mappings"].Elem.(*schema.Resource)
+	validateFn := mappings.Schema["strictness"].ValidateFunc
+
+	for _, value := range []string{"strict", "passthrough"} {
+		_, errs := validateFn(value, "strictness")
+		assert.Empty(t, errs, "strictness %q", value)
+	}
+
+	for _, value := range []string{"", "Strict", "lenient"} {
+		_, errs := validateFn(value, "strictness")
+		assert.NotEmpty(t, errs, "strictness %q", value)
+	}
+}
+
+func TestWrapAccessApplicationDestinationsError(t *testing.T) {
+	t.Parallel()
+
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"success": false, "errors": [{"code": 12130, "message": "access.api.error.forbidden"}], "messages": [], "result": null}`)
+	})
+
+	privateDestinations := []cloudflare.AccessDestination{
+		{Type: cloudflare.AccessDestinationPublic, URI: "example.com"},
+		{Type: cloudflare.AccessDestinationPrivate, URI: "10.0.0.1"},
+	}
+	_, err := client.CreateAccessApplication(context.Background(), cloudflare.AccountIdentifier("account-id"), cloudflare.CreateAccessApplicationParams{
+		Name:         "example",
+		Type:         cloudflare.SelfHosted,
+		Destinations: privateDestinations,
+	})
+	assert.Error(t, err)
+
+	wrapped := wrapAccessApplicationDestinationsError(err, privateDestinations)
+	assert.ErrorContains(t, wrapped, "private destinations early access feature")
+	assert.ErrorIs(t, wrapped, err)
+
+	publicDestinations := privateDestinations[:1]
+	assert.Equal(t, err, wrapAccessApplicationDestinationsError(err, publicDestinations))
+
+	requestError := cloudflare.NewRequestError(&cloudflare.Error{StatusCode: http.StatusBadRequest, ErrorMessages: []string{"invalid private destination"}})
+	assert.Equal(t, error(requestError), wrapAccessApplicationDestinationsError(requestError, privateDestinations))
+	assert.NoError(t, wrapAccessApplicationDestinationsError(nil, privateDestinations))
+}
+
+func TestAccessApplicationReadRefreshesSaasPublicKey(t *testing.T) {
+	t.Parallel()
+
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "app-id",
+				"name": "example",
+				"type": "saas",
+				"saas_app": {
+					"auth_type": "saml",
+					"sp_entity_id": "saas-app.example",
+					"public_key": "rotated-certificate"
+				}
+			}
+		}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		consts.AccountIDSchemaKey: "account-id",
+		"name":                    "example",
+		"type":                    "saas",
+	})
+	d.SetId("app-id")
+	assert.NoError(t, d.Set("saas_app", []interface{}{map[string]interface{}{
+		"auth_type":    "saml",
+		"sp_entity_id": "saas-app.example",
+		"public_key":   "original-certificate",
+	}}))
+
+	diags := resourceCloudflareAccessApplicationRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "rotated-certificate", d.Get("saas_app.0.public_key"))
+}
+
+func TestCheckAccessApplicationPoliciesUnchanged(t *testing.T) {
+	t.Parallel()
+
+	// The API reports a policy attached by another apply between the plan
+	// and this update.
+	var requests []string
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "app-id",
+				"name": "example",
+				"type": "self_hosted",
+				"policies": [
+					{"id": "policy-1", "precedence": 1},
+					{"id": "policy-concurrent", "precedence": 2}
+				]
+			}
+		}`)
+	})
+
+	identifier := cloudflare.AccountIdentifier("account-id")
+
+	err := checkAccessApplicationPoliciesUnchanged(context.Background(), client, identifier, "app-id", []string{"policy-1"})
+	assert.ErrorContains(t, err, "were modified outside of this apply")
+
+	err = checkAccessApplicationPoliciesUnchanged(context.Background(), client, identifier, "app-id", []string{"policy-concurrent", "policy-1"})
+	assert.ErrorContains(t, err, "were modified outside of this apply")
+
+	err = checkAccessApplicationPoliciesUnchanged(context.Background(), client, identifier, "app-id", []string{"policy-1", "policy-concurrent"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{http.MethodGet, http.MethodGet, http.MethodGet}, requests)
+}
+
+func TestAccessApplicationSkipInterstitialWarning(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		appType          string
+		skipInterstitial bool
+		expectWarning    bool
+	}{
+		{"saas", true, true},
+		{"bookmark", true, true},
+		{"app_launcher", true, true},
+		{"saas", false, false},
+		{"ssh", true, false},
+		{"self_hosted", true, false},
+	}
+
+	for _, c := range cases {
+		warning := accessApplicationSkipInterstitialWarning(c.appType, c.skipInterstitial)
+		assert.Equal(t, c.expectWarning, warning != "", "type %q with skip_interstitial %t", c.appType, c.skipInterstitial)
+	}
+
+	assert.Contains(t, accessApplicationSkipInterstitialWarning("saas", true), `"saas" applications`)
+
+	resp := &schema.ValidateResourceConfigFuncResponse{}
+	warnAccessApplicationSkipInterstitial(context.Background(), schema.ValidateResourceConfigFuncRequest{
+		RawConfig: testRawResourceConfig(resourceCloudflareAccessApplication(), map[string]cty.Value{
+			"type":              cty.StringVal("saas"),
+			"skip_interstitial": cty.True,
+		}),
+	}, resp)
+	assert.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, diag.Warning, resp.Diagnostics[0].Severity)
+	assert.Equal(t, cty.GetAttrPath("skip_interstitial"), resp.Diagnostics[0].AttributePath)
+
+	for _, appType := range []cty.Value{cty.NullVal(cty.String), cty.UnknownVal(cty.String)} {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		warnAccessApplicationSkipInterstitial(context.Background(), schema.ValidateResourceConfigFuncRequest{
+			RawConfig: testRawResourceConfig(resourceCloudflareAccessApplication(), map[string]cty.Value{
+				"type":              appType,
+				"skip_interstitial": cty.True,
+			}),
+		}, resp)
+		assert.Empty(t, resp.Diagnostics, "type %#v", appType)
+	}
+}
+
+// testRawResourceConfig builds the raw configuration Terraform sends for a
+// resource, leaving every attribute that isn't listed in attrs unset.
+func testRawResourceConfig(r *schema.Resource, attrs map[string]cty.Value) cty.Value {
+	values := map[string]cty.Value{}
+	for name, ty := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		values[name] = cty.NullVal(ty)
+	}
+	for name, value := range attrs {
+		values[name] = value
+	}
+
+	return cty.ObjectVal(values)
+}
+
+func TestAccessApplicationSCIMAuthenticationOrdering(t *testing.T) {
+	t.Parallel()
+
+	tokenAuth := &cloudflare.AccessApplicationScimAuthenticationOauthBearerToken{Token: "beepboop"}
+	tokenAuth.Scheme = cloudflare.AccessApplicationScimAuthenticationSchemeOauthBearerToken
+	serviceTokenAuth := &cloudflare.AccessApplicationScimAuthenticationServiceToken{ClientID: "1234", ClientSecret: "5678"}
+	serviceTokenAuth.Scheme = cloudflare.AccessApplicationScimAuthenticationAccessServiceToken
+
+	schemes := func(auth []interface{}) []string {
+		var s []string
+		for _, a := range auth {
+			s = append(s, fmt.Sprint(a.(map[string]interface{})["scheme"]))
+		}
+		return s
+	}
+
+	forward := convertScimConfigAuthenticationStructToSchema(&cloudflare.AccessApplicationScimAuthenticationJson{
+		Value: &cloudflare.AccessApplicationMultipleScimAuthentication{{Value: tokenAuth}, {Value: serviceTokenAuth}},
+	})
+	reversed := convertScimConfigAuthenticationStructToSchema(&cloudflare.AccessApplicationScimAuthenticationJson{
+		Value: &cloudflare.AccessApplicationMultipleScimAuthentication{{Value: serviceTokenAuth}, {Value: tokenAuth}},
+	})
+	assert.Equal(t, forward, reversed)
+	assert.Equal(t, []string{"access_service_token", "oauthbearertoken"}, schemes(forward))
+
+	// Entries keep the order they were configured in.
+	prior := []interface{}{
+		map[string]interface{}{"scheme": "oauthbearertoken"},
+		map[string]interface{}{"scheme": "access_service_token"},
+	}
+	assert.Equal(t, []string{"oauthbearertoken", "access_service_token"}, schemes(sortByPriorOrder(forward, prior, "scheme")))
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		"scim_config": []interface{}{map[string]interface{}{
+			"enabled":    true,
+			"remote_uri": "https://scim.com",
+			"idp_uid":    "idp-id",
+			"authentication": []interface{}{
+				map[string]interface{}{"scheme": "oauthbearertoken", "token": "beepboop"},
+				map[string]interface{}{"scheme": "access_service_token", "client_id": "1234", "client_secret": "5678"},
+			},
+		}},
+	})
+	multi := convertScimConfigAuthenticationSchemaToStruct(d).Value.(*cloudflare.AccessApplicationMultipleScimAuthentication)
+	assert.Len(t, *multi, 2)
+	assert.Equal(t, serviceTokenAuth, (*multi)[0].Value)
+	assert.Equal(t, tokenAuth, (*multi)[1].Value)
+}
+
+func TestAccessApplicationReadPreservesCustomClaimOrder(t *testing.T) {
+	t.Parallel()
+
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "app-id",
+				"name": "example",
+				"type": "saas",
+				"saas_app": {
+					"auth_type": "oidc",
+					"client_id": "client-id",
+					"custom_claims": [
+						{"name": "department", "scope": "profile", "source": {"name": "department"}},
+						{"name": "rank", "scope": "profile", "source": {"name": "rank"}}
+					]
+				}
+			}
+		}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		consts.AccountIDSchemaKey: "account-id",
+		"name":                    "example",
+		"type":                    "saas",
+	})
+	d.SetId("app-id")
+	assert.NoError(t, d.Set("saas_app", []interface{}{map[string]interface{}{
+		"auth_type": "oidc",
+		"client_id": "client-id",
+		"custom_claim": []interface{}{
+			map[string]interface{}{"name": "rank", "scope": "profile", "source": []interface{}{map[string]interface{}{"name": "rank"}}},
+			map[string]interface{}{"name": "department", "scope": "profile", "source": []interface{}{map[string]interface{}{"name": "department"}}},
+		},
+	}}))
+
+	diags := resourceCloudflareAccessApplicationRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "rank", d.Get("saas_app.0.custom_claim.0.name"))
+	assert.Equal(t, "department", d.Get("saas_app.0.custom_claim.1.name"))
+}
+
+func TestAccessApplicationReadPreservesCustomAttributeOrder(t *testing.T) {
+	t.Parallel()
+
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "app-id",
+				"name": "example",
+				"type": "saas",
+				"saas_app": {
+					"auth_type": "saml",
+					"sp_entity_id": "saas-app.example",
+					"custom_attributes": [
+						{"name": "email", "name_format": "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", "source": {"name": "user_email"}},
+						{"name": "email", "name_format": "urn:oasis:names:tc:SAML:2.0:attrname-format:uri", "source": {"name": "user_email"}},
+						{"name": "rank", "source": {"name": "rank"}}
+					]
+				}
+			}
+		}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		consts.AccountIDSchemaKey: "account-id",
+		"name":                    "example",
+		"type":                    "saas",
+	})
+	d.SetId("app-id")
+	assert.NoError(t, d.Set("saas_app", []interface{}{map[string]interface{}{
+		"auth_type":    "saml",
+		"sp_entity_id": "saas-app.example",
+		"custom_attribute": []interface{}{
+			map[string]interface{}{"name": "rank", "source": []interface{}{map[string]interface{}{"name": "rank"}}},
+			map[string]interface{}{"name": "email", "name_format": "urn:oasis:names:tc:SAML:2.0:attrname-format:uri", "source": []interface{}{map[string]interface{}{"name": "user_email"}}},
+			map[string]interface{}{"name": "email", "name_format": "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", "source": []interface{}{map[string]interface{}{"name": "user_email"}}},
+		},
+	}}))
+
+	diags := resourceCloudflareAccessApplicationRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "rank", d.Get("saas_app.0.custom_attribute.0.name"))
+	assert.Equal(t, "urn:oasis:names:tc:SAML:2.0:attrname-format:uri", d.Get("saas_app.0.custom_attribute.1.name_format"))
+	assert.Equal(t, "urn:oasis:names:tc:SAML:2.0:attrname-format:basic", d.Get("saas_app.0.custom_attribute.2.name_format"))
+}
+
+func TestAccessApplicationImportFallsBackToZone(t *testing.T) {
+	t.Parallel()
+
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/accounts/") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"success": false, "errors": [{"code": 7003, "message": "Could not route to /accounts/identifier/access/apps/app-id"}], "messages": [], "result": null}`)
+			return
+		}
+
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {"id": "app-id", "name": "example", "type": "self_hosted", "domain": "example.com"}
+		}`)
+	})
+
+	for _, id := range []string{"identifier/app-id", "zone/identifier/app-id"} {
+		d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{})
+		d.SetId(id)
+
+		imported, err := resourceCloudflareAccessApplicationImport(context.Background(), d, client)
+		assert.NoError(t, err, "id %q", id)
+		assert.Equal(t, "identifier", imported[0].Get(consts.ZoneIDSchemaKey), "id %q", id)
+		assert.Equal(t, "", imported[0].Get(consts.AccountIDSchemaKey), "id %q", id)
+		assert.Equal(t, "app-id", imported[0].Id(), "id %q", id)
+		assert.Equal(t, "example", imported[0].Get("name"), "id %q", id)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{})
+	d.SetId("organization/identifier/app-id")
+	_, err := resourceCloudflareAccessApplicationImport(context.Background(), d, client)
+	assert.ErrorContains(t, err, "invalid id")
+}
+
+func TestAccessApplicationWithoutPoliciesWarning(t *testing.T) {
+	t.Parallel()
+
+	for _, appType := range []string{"self_hosted", "ssh", "vnc", "saas"} {
+		assert.NotEmpty(t, accessApplicationWithoutPoliciesWarning(appType, 0), "type %q", appType)
+		assert.Empty(t, accessApplicationWithoutPoliciesWarning(appType, 1), "type %q", appType)
+	}
+
+	for _, appType := range []string{"bookmark", "infrastructure", "app_launcher", "warp"} {
+		assert.Empty(t, accessApplicationWithoutPoliciesWarning(appType, 0), "type %q", appType)
+	}
+
+	cases := []struct {
+		policies      cty.Value
+		expectWarning bool
+	}{
+		// Policies managed through cloudflare_access_policy.application_id.
+		{cty.NullVal(cty.List(cty.String)), false},
+		{cty.ListValEmpty(cty.String), true},
+		{cty.ListVal([]cty.Value{cty.StringVal("policy-id")}), false},
+		{cty.ListVal([]cty.Value{cty.UnknownVal(cty.String)}), false},
+		{cty.UnknownVal(cty.List(cty.String)), false},
+	}
+
+	for _, c := range cases {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		warnAccessApplicationWithoutPolicies(context.Background(), schema.ValidateResourceConfigFuncRequest{
+			RawConfig: testRawResourceConfig(resourceCloudflareAccessApplication(), map[string]cty.Value{
+				"policies": c.policies,
+			}),
+		}, resp)
+		assert.Equal(t, c.expectWarning, len(resp.Diagnostics) == 1, "policies %#v", c.policies)
+		if c.expectWarning {
+			assert.Equal(t, diag.Warning, resp.Diagnostics[0].Severity)
+			assert.Contains(t, resp.Diagnostics[0].Summary, `"self_hosted" application through policies`)
+			assert.NotContains(t, resp.Diagnostics[0].Summary, "not be reachable")
+		}
+	}
+}
+
+func TestAccessApplicationPKCEWithoutGrantWarning(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		allowPKCE     bool
+		grantTypes    []interface{}
+		expectWarning bool
+	}{
+		{true, []interface{}{"authorization_code"}, true},
+		{true, []interface{}{}, true},
+		{true, []interface{}{"authorization_code_with_pkce"}, false},
+		{false, []interface{}{"authorization_code"}, false},
+	}
+
+	for _, c := range cases {
+		warning := accessApplicationPKCEWithoutGrantWarning(c.allowPKCE, schema.NewSet(schema.HashString, c.grantTypes))
+		assert.Equal(t, c.expectWarning, warning != "", "allow_pkce_without_client_secret %t with grant_types %v", c.allowPKCE, c.grantTypes)
+	}
+
+	saasApp := func(grantTypes cty.Value) map[string]cty.Value {
+		return map[string]cty.Value{
+			"type": cty.StringVal("saas"),
+			"saas_app": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"auth_type":                        cty.StringVal("oidc"),
+				"allow_pkce_without_client_secret": cty.True,
+				"grant_types":                      grantTypes,
+			})}),
+		}
+	}
+
+	resp := &schema.ValidateResourceConfigFuncResponse{}
+	warnAccessApplicationPKCEWithoutGrant(context.Background(), schema.ValidateResourceConfigFuncRequest{
+		RawConfig: testRawResourceConfig(resourceCloudflareAccessApplication(), saasApp(cty.SetVal([]cty.Value{cty.StringVal("authorization_code")}))),
+	}, resp)
+	assert.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, diag.Warning, resp.Diagnostics[0].Severity)
+
+	// grant_types is computed when unset, so nothing is known about it yet.
+	for _, grantTypes := range []cty.Value{cty.NullVal(cty.Set(cty.String)), cty.UnknownVal(cty.Set(cty.String))} {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		warnAccessApplicationPKCEWithoutGrant(context.Background(), schema.ValidateResourceConfigFuncRequest{
+			RawConfig: testRawResourceConfig(resourceCloudflareAccessApplication(), saasApp(grantTypes)),
+		}, resp)
+		assert.Empty(t, resp.Diagnostics, "grant_types %#v", grantTypes)
+	}
+}
+
+func TestAccessApplicationSaasAuthTypeCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	for _, authType := range []string{"oidc", "OIDC", "Oidc"} {
+		d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+			"type": "saas",
+			"saas_app": []interface{}{map[string]interface{}{
+				"auth_type":     authType,
+				"redirect_uris": []interface{}{"https://saas-app.example/sso/oauth2/callback"},
+			}},
+		})
+
+		assert.Equal(t, saasAuthTypeOIDC, saasAuthType(d), "auth_type %q", authType)
+		assert.Equal(t, saasAuthTypeOIDC, convertSaasSchemaToStruct(d).AuthType, "auth_type %q", authType)
+	}
+
+	authTypeSchema := resourceCloudflareAccessApplicationSchema()["saas_app"].Elem.(*schema.Resource).Schema["auth_type"]
+	assert.True(t, authTypeSchema.DiffSuppressFunc("saas_app.0.auth_type", "oidc", "OIDC", nil))
+	assert.False(t, authTypeSchema.DiffSuppressFunc("saas_app.0.auth_type", "saml", "OIDC", nil))
+	_, errs := authTypeSchema.ValidateFunc("SAML", "saas_app.0.auth_type")
+	assert.Empty(t, errs)
+}
+
+func TestAccessApplicationReadSetsOIDCDiscoveryURL(t *testing.T) {
+	t.Parallel()
+
+	organizationRequests := 0
+	organizationAvailable := true
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/access/organizations") {
+			organizationRequests++
+			if !organizationAvailable {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprint(w, `{"success": false, "errors": [{"code": 10000, "message": "internal error"}], "messages": [], "result": null}`)
+				return
+			}
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"name": "example", "auth_domain": "example.cloudflareaccess.com"}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": {
+				"id": "app-id",
+				"name": "example",
+				"type": "saas",
+				"saas_app": {"auth_type": "oidc", "client_id": "client-id"}
+			}
+		}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		consts.AccountIDSchemaKey: "account-id",
+		"name":                    "example",
+		"type":                    "saas",
+	})
+	d.SetId("app-id")
+
+	diags := resourceCloudflareAccessApplicationRead(context.Background(), d, client)
+	assert.Empty(t, diags)
+	assert.Equal(t, "https://example.cloudflareaccess.com/cdn-cgi/access/sso/oidc/client-id/.well-known/openid-configuration", d.Get("saas_app.0.oidc_discovery_url"))
+	assert.Equal(t, 1, organizationRequests)
+
+	// The URL in state is reused while the client ID doesn't change.
+	organizationAvailable = false
+	diags = resourceCloudflareAccessApplicationRead(context.Background(), d, client)
+	assert.Empty(t, diags)
+	assert.Equal(t, "https://example.cloudflareaccess.com/cdn-cgi/access/sso/oidc/client-id/.well-known/openid-configuration", d.Get("saas_app.0.oidc_discovery_url"))
+	assert.Equal(t, 1, organizationRequests)
+
+	d = schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		consts.AccountIDSchemaKey: "account-id",
+		"name":                    "example",
+		"type":                    "saas",
+	})
+	d.SetId("app-id")
+
+	diags = resourceCloudflareAccessApplicationRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Empty(t, d.Get("saas_app.0.oidc_discovery_url"))
+	assert.Equal(t, 2, organizationRequests)
+}
+
+func TestValidateNameIDTransformJsonata(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		expression  string
+		expectWarns int
+	}{
+		{"", 0},
+		{"$substringBefore(email, '@') & '+sandbox@' & $substringAfter(email, '@')", 0},
+		{"email", 0},
+		{"[email]", 1},
+		{"  [email, name]", 1},
+		{"{'email': email}", 1},
+	}
+
+	for _, c := range cases {
+		warns, errs := validateNameIDTransformJsonata(c.expression, "saas_app.0.name_id_transform_jsonata")
+		assert.Len(t, warns, c.expectWarns, "expression %q", c.expression)
+		assert.Empty(t, errs, "expression %q", c.expression)
+	}
+}
+
+func TestValidateSPEntityID(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		entityID    string
+		expectWarns int
+	}{
+		{"", 0},
+		{"saas-app.example", 1},
+		{"my-app", 1},
+		{"https://saas-app.example/metadata", 0},
+		{"urn:amazon:webservices", 0},
+		{"URN:example:app", 0},
+	}
+
+	for _, c := range cases {
+		warns, errs := validateSPEntityID(c.entityID, "saas_app.0.sp_entity_id")
+		assert.Len(t, warns, c.expectWarns, "entity id %q", c.entityID)
+		assert.Empty(t, errs, "entity id %q", c.entityID)
+	}
+}
+
+func TestValidateSAMLAttributeNameFormat(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		nameFormat  string
+		expectWarns int
+		expectErrs  int
+	}{
+		{"", 0, 0},
+		{"urn:oasis:names:tc:SAML:2.0:attrname-format:unspecified", 0, 0},
+		{"urn:oasis:names:tc:SAML:2.0:attrname-format:basic", 0, 0},
+		{"urn:oasis:names:tc:SAML:2.0:attrname-format:uri", 0, 0},
+		{"urn:example:attrname-format:custom", 1, 0},
+		{"basic", 0, 1},
+	}
+
+	for _, c := range cases {
+		warns, errs := validateSAMLAttributeNameFormat(c.nameFormat, "saas_app.0.custom_attribute.0.name_format")
+		assert.Len(t, warns, c.expectWarns, "name format %q", c.nameFormat)
+		assert.Len(t, errs, c.expectErrs, "name format %q", c.nameFormat)
+	}
+}
+
+func TestConvertOIDCClaimStructToSchemaNameByIDP(t *testing.T) {
+	t.Parallel()
+
+	claim := convertOIDCClaimStructToSchema(cloudflare.OIDCClaimConfig{
+		Name:   "rank",
+		Source: cloudflare.SourceConfig{Name: "rank"},
+	})
+	source := claim["source"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "rank", source["name"])
+	assert.NotContains(t, source, "name_by_idp")
+
+	claim = convertOIDCClaimStructToSchema(cloudflare.OIDCClaimConfig{
+		Name: "rank",
+		Source: cloudflare.SourceConfig{
+			Name:      "rank",
+			NameByIDP: map[string]string{"idp-id": "idp_rank"},
+		},
+	})
+	source = claim["source"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]string{"idp-id": "idp_rank"}, source["name_by_idp"])
+}
+
+func TestAccessApplicationSelfHostedDomainsDeprecationWarning(t *testing.T) {
+	t.Parallel()
+
+	diags := resourceCloudflareZeroTrustAccessApplication().Validate(tfsdkv2.NewResourceConfigRaw(map[string]interface{}{
+		consts.AccountIDSchemaKey: "identifier",
+		"name":                    "example",
+		"self_hosted_domains":     []interface{}{"example.com"},
+	}))
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, "destinations")
+	assert.Contains(t, diags[0].Detail, "https://registry.terraform.io/")
+}
+
+func TestAccessApplicationTagsMaxItems(t *testing.T) {
+	t.Parallel()
+
+	tags := make([]interface{}, accessApplicationMaxTags+1)
+	for i := range tags {
+		tags[i] = fmt.Sprintf("tag-%d", i)
+	}
+
+	config := func(tags []interface{}) *tfsdkv2.ResourceConfig {
+		return tfsdkv2.NewResourceConfigRaw(map[string]interface{}{
+			consts.AccountIDSchemaKey: "identifier",
+			"name":                    "example",
+			"domain":                  "example.com",
+			"tags":                    tags,
+		})
+	}
+
+	r := resourceCloudflareZeroTrustAccessApplication()
+	_, err := r.Diff(context.Background(), nil, config(tags[:accessApplicationMaxTags]), nil)
+	assert.NoError(t, err)
+
+	_, err = r.Diff(context.Background(), nil, config(tags), nil)
+	assert.EqualError(t, err, "tags lists 11 tags but Cloudflare allows at most 10 tags per Access Application")
+}
+
+func TestAccessApplicationDomainType(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]string{
+		"10.0.0.1":                "private",
+		"10.0.0.0/24":             "private",
+		"10.0.0.1/admin":          "private",
+		"2001:db8::1":             "private",
+		"example.com":             "public",
+		"app.example.com/admin/*": "public",
+	}
+
+	for domain, expected := range cases {
+		assert.Equal(t, expected, accessApplicationDomainType(domain), "domain %q", domain)
+	}
+}
+
+func TestAccessApplicationInferDomainType(t *testing.T) {
+	t.Parallel()
+
+	r := resourceCloudflareZeroTrustAccessApplication()
+	plan := func(state *tfsdkv2.InstanceState, domain string) *tfsdkv2.InstanceDiff {
+		state.RawConfig = testRawResourceConfig(r, map[string]cty.Value{
+			consts.AccountIDSchemaKey: cty.StringVal("account-id"),
+			"name":                    cty.StringVal("example"),
+			"domain":                  cty.StringVal(domain),
+		})
+		diff, err := r.Diff(context.Background(), state, tfsdkv2.NewResourceConfigRaw(map[string]interface{}{
+			consts.AccountIDSchemaKey: "account-id",
+			"name":                    "example",
+			"domain":                  domain,
+		}), nil)
+		assert.NoError(t, err)
+		return diff
+	}
+
+	created := plan(&tfsdkv2.InstanceState{}, "10.0.0.1")
+	assert.Equal(t, "private", created.Attributes["domain_type"].New)
+
+	existing := func(domainType string) *tfsdkv2.InstanceState {
+		return &tfsdkv2.InstanceState{ID: "app-id", Attributes: map[string]string{
+			consts.AccountIDSchemaKey: "account-id",
+			"name":                    "example",
+			"domain":                  "example.com",
+			"domain_type":             domainType,
+			"type":                    "self_hosted",
+		}}
+	}
+
+	// Existing applications aren't reclassified until their domain changes.
+	if unchanged := plan(existing(""), "example.com"); unchanged != nil {
+		assert.NotContains(t, unchanged.Attributes, "domain_type")
+	}
+
+	changed := plan(existing("public"), "10.0.0.1")
+	assert.Equal(t, "private", changed.Attributes["domain_type"].New)
+}
+
+func TestSuppressUnsetSSOEndpoint(t *testing.T) {
+	t.Parallel()
+
+	assigned := "https://example.cloudflareaccess.com/cdn-cgi/access/sso/saml/app-id"
+	assert.True(t, suppressUnsetSSOEndpoint("saas_app.0.sso_endpoint", assigned, "", nil))
+	assert.False(t, suppressUnsetSSOEndpoint("saas_app.0.sso_endpoint", assigned, "https://sso.example.com/saml", nil))
+}
+
+func TestConvertSaasSAMLSchemaOmitsUnsetSSOEndpoint(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		"saas_app": []interface{}{
+			map[string]interface{}{
+				"sp_entity_id": "saas-app.example",
+				"sso_endpoint": "https://example.cloudflareaccess.com/cdn-cgi/access/sso/saml/app-id",
+			},
+		},
+	})
+
+	samlConfig := convertSaasSAMLSchemaToStruct(d)
+	assert.Empty(t, samlConfig.SSOEndpoint)
+	assert.Equal(t, "saas-app.example", samlConfig.SPEntityID)
+}
+
+func TestAccessApplicationFooterLinksError(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, accessApplicationFooterLinksError([]interface{}{
+		map[string]interface{}{"name": "footer link", "url": "https://www.cloudflare.com"},
+	}))
+
+	err := accessApplicationFooterLinksError([]interface{}{
+		map[string]interface{}{"name": "footer link", "url": "https://www.cloudflare.com"},
+		map[string]interface{}{"name": "", "url": "https://example.com"},
+	})
+	assert.EqualError(t, err, `footer_links with url "https://example.com" must also set name`)
+}
+
+func TestAccessApplicationFooterLinksURLValidation(t *testing.T) {
+	t.Parallel()
+
+	footerLinks := resourceCloudflareAccessApplicationSchema()["footer_links"].Elem.(*schema.Resource)
+	validateFn := footerLinks.Schema["url"].ValidateFunc
+
+	_, errs := validateFn("https://www.cloudflare.com", "url")
+	assert.Empty(t, errs)
+
+	for _, value := range []string{"www.cloudflare.com", "/relative", "ftp://example.com"} {
+		_, errs := validateFn(value, "url")
+		assert.NotEmpty(t, errs, "url %q", value)
+	}
+}
+
+func TestSuppressNullOrEmptyMap(t *testing.T) {
+	t.Parallel()
+
+	key := "saas_app.0.custom_claim.0.source.0.name_by_idp"
+	cases := []struct {
+		key      string
+		oldValue string
+		newValue string
+		suppress bool
+	}{
+		{key + ".%", "", "0", true},
+		{key + ".%", "0", "", true},
+		{key + ".%", "0", "0", true},
+		{key + ".%", "", "1", false},
+		{key + ".%", "1", "0", false},
+		{key + ".idp-id", "", "idp_rank", false},
+	}
+
+	for _, c := range cases {
+		suppress := suppressNullOrEmptyMap(c.key, c.oldValue, c.newValue, nil)
+		assert.Equal(t, c.suppress, suppress, "%s: %q -> %q", c.key, c.oldValue, c.newValue)
+	}
+}
+
+func TestConvertSAMLAttributeStructToSchemaNullNameByIDP(t *testing.T) {
+	t.Parallel()
+
+	attribute := convertSAMLAttributeStructToSchema(cloudflare.SAMLAttributeConfig{
+		Name:   "email",
+		Source: cloudflare.SourceConfig{Name: "user_email", NameByIDP: nil},
+	})
+	source := attribute["source"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "user_email", source["name"])
+	assert.NotContains(t, source, "name_by_idp")
+
+	// A configured empty map plans "0" entries against the null from the API.
+	assert.True(t, suppressNullOrEmptyMap("saas_app.0.custom_attribute.0.source.0.name_by_idp.%", "", "0", nil))
+	assert.True(t, suppressNullOrEmptyMap("saas_app.0.custom_attribute.0.source.0.name_by_idp.%", "0", "", nil))
+}
+
+func TestHashAccessApplicationSCIMMapping(t *testing.T) {
+	t.Parallel()
+
+	mapping := map[string]interface{}{
+		"schema":            "urn:ietf:params:scim:schemas:core:2.0:User",
+		"filter":            "userType eq \"Intern\"",
+		"transform_jsonata": "$",
+	}
+	updated := map[string]interface{}{
+		"schema":            "urn:ietf:params:scim:schemas:core:2.0:User",
+		"filter":            "userType eq \"Intern\"",
+		"transform_jsonata": "$merge([$, {'active': false}])",
+	}
+	otherFilter := map[string]interface{}{
+		"schema": "urn:ietf:params:scim:schemas:core:2.0:User",
+		"filter": "userType eq \"Employee\"",
+	}
+
+	assert.Equal(t, hashAccessApplicationSCIMMapping(mapping), hashAccessApplicationSCIMMapping(updated))
+	assert.NotEqual(t, hashAccessApplicationSCIMMapping(mapping), hashAccessApplicationSCIMMapping(otherFilter))
+}
+
+func TestSuppressAppLauncherCustomization(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		appType  string
+		expected bool
+	}{
+		{"app_launcher", false},
+		{"self_hosted", true},
+		{"saas", true},
+	}
+
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+			"type": c.appType,
+		})
+		got := suppressAppLauncherCustomization("bg_color", "#000000", "", d)
+		assert.Equal(t, c.expected, got, "type %q", c.appType)
+	}
+}
+
+func TestConvertTargetContextsIgnoresAttributeOrder(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		"type": "infrastructure",
+		"target_criteria": []interface{}{
+			map[string]interface{}{
+				"port":     22,
+				"protocol": "SSH",
+				"target_attributes": []interface{}{
+					map[string]interface{}{"name": "hostname", "values": []interface{}{"tfgo-acc-test"}},
+					map[string]interface{}{"name": "environment", "values": []interface{}{"staging", "production"}},
+				},
+			},
+		},
+	})
+	configured := d.Get("target_criteria.0.target_attributes").(*schema.Set)
+
+	contexts, err := convertTargetContextsToStruct(d)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"hostname":    {"tfgo-acc-test"},
+		"environment": {"staging", "production"},
+	}, (*contexts)[0].TargetAttributes)
+
+	// The API returns target attributes as a map so the order is not stable
+	// between reads, setting them back must not produce a different set.
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, d.Set("target_criteria", convertTargetContextsToSchema(contexts)))
+		assert.True(t, configured.Equal(d.Get("target_criteria.0.target_attributes")))
+	}
+}
+
+func TestConvertSaasStructToSchemaPreservesClientID(t *testing.T) {
+	t.Parallel()
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareAccessApplicationSchema(), map[string]interface{}{
+		"type": "saas",
+	})
+	assert.NoError(t, d.Set("saas_app", []interface{}{map[string]interface{}{
+		"auth_type": saasAuthTypeOIDC,
+		"client_id": "known-client-id",
+	}}))
+
+	saasApp := convertSaasStructToSchema(d, &cloudflare.SaasApplication{AuthType: saasAuthTypeOIDC})
+	assert.Equal(t, "known-client-id", saasApp[0].(map[string]interface{})["client_id"])
+
+	saasApp = convertSaasStructToSchema(d, &cloudflare.SaasApplication{AuthType: saasAuthTypeOIDC, ClientID: "new-client-id"})
+	assert.Equal(t, "new-client-id", saasApp[0].(map[string]interface{})["client_id"])
+}
@@ -3,13 +3,16 @@ package sdkv2provider
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/cloudflare/terraform-provider-cloudflare/internal/consts"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,6 +26,8 @@ func resourceCloudflareTeamsLocation() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareTeamsLocationImport,
 		},
+		CustomizeDiff:                  resourceCloudflareTeamsLocationCustomizeDiff(),
+		ValidateRawResourceConfigFuncs: resourceCloudflareTeamsLocationValidateRawConfig(),
 		Description: heredoc.Doc(`
 			Provides a Cloudflare Teams Location resource. Teams Locations are
 			referenced when creating secure web gateway policies.
@@ -41,6 +46,8 @@ func resourceCloudflareZeroTrustDNSLocation() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceCloudflareTeamsLocationImport,
 		},
+		CustomizeDiff:                  resourceCloudflareTeamsLocationCustomizeDiff(),
+		ValidateRawResourceConfigFuncs: resourceCloudflareTeamsLocationValidateRawConfig(),
 		Description: heredoc.Doc(`
 			Provides a Cloudflare Teams Location resource. Teams Locations are
 			referenced when creating secure web gateway policies.
@@ -48,6 +55,106 @@ func resourceCloudflareZeroTrustDNSLocation() *schema.Resource {
 	}
 }
 
+func resourceCloudflareTeamsLocationCustomizeDiff() schema.CustomizeDiffFunc {
+	return customdiff.All(
+		validateTeamsLocationClientDefaultEndpoints,
+	)
+}
+
+// resourceCloudflareTeamsLocationValidateRawConfig groups the checks that
+// only warn. CustomizeDiff functions cannot return warning diagnostics, so
+// these run against the raw configuration during validation instead.
+func resourceCloudflareTeamsLocationValidateRawConfig() []schema.ValidateRawResourceConfigFunc {
+	return []schema.ValidateRawResourceConfigFunc{
+		warnTeamsLocationDuplicateEndpointNetworks,
+		warnTeamsLocationECSSupportWithoutIPv4Endpoint,
+	}
+}
+
+// validateTeamsLocationClientDefaultEndpoints rejects a default location for
+// clients without an enabled IPv4 endpoint, which the API requires. This
+// replaces the earlier warning for default locations with neither an IPv4 nor
+// an IPv6 endpoint, every configuration it warned about is now an error.
+func validateTeamsLocationClientDefaultEndpoints(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("client_default") || !d.NewValueKnown("endpoints") {
+		return nil
+	}
+
+	return teamsLocationClientDefaultEndpointsError(
+		d.Get("client_default").(bool),
+		d.Get("endpoints.#").(int) > 0,
+		d.Get("endpoints.0.ipv4.0.enabled").(bool),
+	)
+}
+
+func teamsLocationClientDefaultEndpointsError(clientDefault, hasEndpoints, ipv4Enabled bool) error {
+	if !clientDefault {
+		return nil
+	}
+
+	if !hasEndpoints {
+		return fmt.Errorf("endpoints must be configured when client_default is true")
+	}
+
+	if !ipv4Enabled {
+		return fmt.Errorf("endpoints.0.ipv4.0.enabled must be true when client_default is true")
+	}
+
+	return nil
+}
+
+// warnTeamsLocationDuplicateEndpointNetworks warns when an endpoint lists
+// the same network more than once, only one of them is sent to the API.
+func warnTeamsLocationDuplicateEndpointNetworks(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	for _, endpoint := range []string{"ipv6", "doh", "dot"} {
+		rawNetworks := getRawValue(fmt.Sprintf("endpoints.0.%s.0.networks", endpoint), req.RawConfig)
+		if rawNetworks.IsNull() || !rawNetworks.IsWhollyKnown() || !rawNetworks.CanIterateElements() {
+			continue
+		}
+
+		networks := make([]interface{}, 0, rawNetworks.LengthInt())
+		for i := range rawNetworks.AsValueSlice() {
+			if network, ok := getRawConfigString(rawNetworks, fmt.Sprintf("%d.network", i)); ok {
+				networks = append(networks, map[string]interface{}{"network": network})
+			}
+		}
+
+		if _, duplicates := dedupeTeamsLocationNetworks(networks); len(duplicates) > 0 {
+			resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("endpoints.0.%s.0.networks lists %s more than once, duplicates are ignored", endpoint, strings.Join(duplicates, ", ")),
+				AttributePath: cty.GetAttrPath("endpoints").IndexInt(0).GetAttr(endpoint).IndexInt(0).GetAttr("networks"),
+			})
+		}
+	}
+}
+
+// warnTeamsLocationECSSupportWithoutIPv4Endpoint warns when EDNS Client
+// Subnet support is enabled but the IPv4 endpoint it relies on isn't.
+func warnTeamsLocationECSSupportWithoutIPv4Endpoint(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	ecsSupport, _ := getRawConfigBool(req.RawConfig, "ecs_support")
+	ipv4Enabled, ok := getRawConfigBool(req.RawConfig, "endpoints.0.ipv4.0.enabled")
+	if !ok {
+		return
+	}
+
+	if warning := teamsLocationECSSupportWithoutIPv4EndpointWarning(ecsSupport, ipv4Enabled); warning != "" {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       warning,
+			AttributePath: cty.GetAttrPath("ecs_support"),
+		})
+	}
+}
+
+func teamsLocationECSSupportWithoutIPv4EndpointWarning(ecsSupport, ipv4Enabled bool) string {
+	if !ecsSupport || ipv4Enabled {
+		return ""
+	}
+
+	return "ecs_support has no effect while the ipv4 endpoint is disabled, enable endpoints.0.ipv4.0.enabled to resolve EDNS queries"
+}
+
 func resourceCloudflareTeamsLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*cloudflare.API)
 	accountID := d.Get(consts.AccountIDSchemaKey).(string)
@@ -68,6 +175,9 @@ func resourceCloudflareTeamsLocationRead(ctx context.Context, d *schema.Resource
 	if err := d.Set("networks", flattenTeamsLocationNetworks(location.Networks)); err != nil {
 		return diag.FromErr(fmt.Errorf("error parsing Location networks"))
 	}
+	if err := d.Set("network_ids", flattenTeamsLocationNetworkIDs(location.Networks)); err != nil {
+		return diag.FromErr(fmt.Errorf("error parsing Location network IDs"))
+	}
 
 	if err := d.Set("ip", location.Ip); err != nil {
 		return diag.FromErr(fmt.Errorf("error parsing Location IP"))
@@ -95,10 +205,12 @@ func resourceCloudflareTeamsLocationRead(ctx context.Context, d *schema.Resource
 	}
 
 	if err := d.Set("dns_destination_ips_id", location.DNSDestinationIPsID); err != nil {
-		return diag.FromErr(fmt.Errorf("error parsing Location dns_destination_ipv6_block_id"))
+		return diag.FromErr(fmt.Errorf("error parsing Location dns_destination_ips_id"))
 	}
 
-	if err := d.Set("endpoints", flattenTeamsEndpoints(location.Endpoints)); err != nil {
+	endpoints := flattenTeamsEndpoints(location.Endpoints)
+	preserveTeamsLocationEndpointNetworkDuplicates(endpoints, d.Get("endpoints"))
+	if err := d.Set("endpoints", endpoints); err != nil {
 		return diag.FromErr(fmt.Errorf("error parsing Location endpoints"))
 	}
 
@@ -113,10 +225,11 @@ func resourceCloudflareTeamsLocationCreate(ctx context.Context, d *schema.Resour
 		return diag.FromErr(fmt.Errorf("error creating Teams Location for account %q: %w, %v", accountID, err, networks))
 	}
 	newTeamLocation := cloudflare.TeamsLocation{
-		Name:          d.Get("name").(string),
-		Networks:      networks,
-		ClientDefault: d.Get("client_default").(bool),
-		ECSSupport:    cloudflare.BoolPtr(d.Get("ecs_support").(bool)),
+		Name:                  d.Get("name").(string),
+		Networks:              networks,
+		ClientDefault:         d.Get("client_default").(bool),
+		ECSSupport:            cloudflare.BoolPtr(d.Get("ecs_support").(bool)),
+		AnonymizedLogsEnabled: d.Get("anonymized_logs_enabled").(bool),
 	}
 
 	endpoints, err := inflateTeamsLocationEndpoint(d.Get("endpoints"))
@@ -153,11 +266,12 @@ func resourceCloudflareTeamsLocationUpdate(ctx context.Context, d *schema.Resour
 		return diag.FromErr(fmt.Errorf("error updating Teams Location for account %q: %w, %v", accountID, err, networks))
 	}
 	updatedTeamsLocation := cloudflare.TeamsLocation{
-		ID:            d.Id(),
-		Name:          d.Get("name").(string),
-		ClientDefault: d.Get("client_default").(bool),
-		ECSSupport:    cloudflare.BoolPtr(d.Get("ecs_support").(bool)),
-		Networks:      networks,
+		ID:                    d.Id(),
+		Name:                  d.Get("name").(string),
+		ClientDefault:         d.Get("client_default").(bool),
+		ECSSupport:            cloudflare.BoolPtr(d.Get("ecs_support").(bool)),
+		AnonymizedLogsEnabled: d.Get("anonymized_logs_enabled").(bool),
+		Networks:              networks,
 	}
 	tflog.Debug(ctx, fmt.Sprintf("Updating Cloudflare Teams Location from struct: %+v", updatedTeamsLocation))
 
@@ -190,11 +304,19 @@ func resourceCloudflareTeamsLocationImport(ctx context.Context, d *schema.Resour
 	attributes := strings.SplitN(d.Id(), "/", 2)
 
 	if len(attributes) != 2 {
-		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/teamsLocationID\"", d.Id())
+		return nil, fmt.Errorf("invalid id (\"%s\") specified, should be in format \"accountID/teamsLocationID\" or \"accountID/name:teamsLocationName\"", d.Id())
 	}
 
 	accountID, teamsLocationID := attributes[0], attributes[1]
 
+	if name, ok := strings.CutPrefix(teamsLocationID, "name:"); ok {
+		id, err := teamsLocationIDByName(ctx, meta.(*cloudflare.API), accountID, name)
+		if err != nil {
+			return nil, err
+		}
+		teamsLocationID = id
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Importing Cloudflare Teams Location: id %s for account %s", teamsLocationID, accountID))
 
 	d.Set(consts.AccountIDSchemaKey, accountID)
@@ -205,6 +327,31 @@ func resourceCloudflareTeamsLocationImport(ctx context.Context, d *schema.Resour
 	return []*schema.ResourceData{d}, nil
 }
 
+// teamsLocationIDByName resolves the name of a Teams Location to its ID. Names
+// are not unique, so a name shared by several locations is rejected.
+func teamsLocationIDByName(ctx context.Context, client *cloudflare.API, accountID, name string) (string, error) {
+	locations, _, err := client.TeamsLocations(ctx, accountID)
+	if err != nil {
+		return "", fmt.Errorf("error listing Teams Locations for account %q: %w", accountID, err)
+	}
+
+	var ids []string
+	for _, location := range locations {
+		if location.Name == name {
+			ids = append(ids, location.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no Teams Location named %q found in account %q", name, accountID)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d Teams Locations named %q found in account %q, import by ID instead: %s", len(ids), name, accountID, strings.Join(ids, ", "))
+	}
+}
+
 func inflateTeamsLocationNetworks(networks interface{}) ([]cloudflare.TeamsLocationNetwork, error) {
 	var networkStructs []cloudflare.TeamsLocationNetwork
 	if networks != nil {
@@ -232,6 +379,8 @@ func inflateTeamsLocationNetworksFromList(networks interface{}) ([]cloudflare.Te
 		if !ok {
 			return nil, fmt.Errorf("error parsing network list")
 		}
+		// The API drops repeated networks, send each of them once.
+		networkList, _ = dedupeTeamsLocationNetworks(networkList)
 		for _, i := range networkList {
 			network, ok := i.(map[string]interface{})
 			if !ok {
@@ -245,6 +394,53 @@ func inflateTeamsLocationNetworksFromList(networks interface{}) ([]cloudflare.Te
 	return networkStructs, nil
 }
 
+// dedupeTeamsLocationNetworks returns the endpoint networks without repeated
+// CIDRs, keeping the first occurrence, along with the CIDRs that were
+// repeated.
+func dedupeTeamsLocationNetworks(networks []interface{}) (unique []interface{}, duplicates []string) {
+	seen := make(map[string]bool, len(networks))
+	for _, i := range networks {
+		network, _ := i.(map[string]interface{})
+		cidr, _ := network["network"].(string)
+		if seen[cidr] {
+			if !contains(duplicates, cidr) {
+				duplicates = append(duplicates, cidr)
+			}
+			continue
+		}
+		seen[cidr] = true
+		unique = append(unique, i)
+	}
+	return unique, duplicates
+}
+
+// preserveTeamsLocationEndpointNetworkDuplicates keeps the endpoint networks
+// from the prior state when they only differ from the API response by the
+// repeated CIDRs it dropped, so configurations listing a CIDR twice don't
+// show a perpetual diff.
+func preserveTeamsLocationEndpointNetworkDuplicates(endpoints []interface{}, prior interface{}) {
+	priorEndpoints, ok := prior.([]interface{})
+	if len(endpoints) == 0 || !ok || len(priorEndpoints) == 0 || priorEndpoints[0] == nil {
+		return
+	}
+
+	current := endpoints[0].(map[string]interface{})
+	previous := priorEndpoints[0].(map[string]interface{})
+	for _, endpoint := range []string{"ipv6", "doh", "dot"} {
+		fields, _ := current[endpoint].([]map[string]interface{})
+		priorFields, _ := previous[endpoint].([]interface{})
+		if len(fields) == 0 || len(priorFields) == 0 || priorFields[0] == nil {
+			continue
+		}
+
+		priorNetworks, _ := priorFields[0].(map[string]interface{})["networks"].([]interface{})
+		unique, duplicates := dedupeTeamsLocationNetworks(priorNetworks)
+		if len(duplicates) > 0 && reflect.DeepEqual(unique, fields[0]["networks"]) {
+			fields[0]["networks"] = priorNetworks
+		}
+	}
+}
+
 func inflateTeamsLocationEndpoint(endpoint interface{}) (*cloudflare.TeamsLocationEndpoints, error) {
 	if endpoint == nil {
 		return nil, nil
@@ -375,6 +571,16 @@ func flattenTeamsLocationNetworks(networks []cloudflare.TeamsLocationNetwork) []
 	return flattenedNetworks
 }
 
+func flattenTeamsLocationNetworkIDs(networks []cloudflare.TeamsLocationNetwork) map[string]interface{} {
+	networkIDs := make(map[string]interface{}, len(networks))
+	for _, net := range networks {
+		if net.ID != "" {
+			networkIDs[net.Network] = net.ID
+		}
+	}
+	return networkIDs
+}
+
 func flattenTeamsLocationNetworksIntoList(networks []cloudflare.TeamsLocationNetwork) []interface{} {
 	var flattenedNetworks []interface{}
 	for _, net := range networks {
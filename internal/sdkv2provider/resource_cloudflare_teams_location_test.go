@@ -3,13 +3,21 @@ package sdkv2provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/cloudflare/cloudflare-go"
 	"github.com/cloudflare/terraform-provider-cloudflare/internal/consts"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tfsdkv2 "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccCloudflareTeamsLocationBasic(t *testing.T) {
@@ -39,6 +47,7 @@ func TestAccCloudflareTeamsLocationBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "ecs_support", "false"),
 					resource.TestCheckResourceAttr(name, "networks.#", "1"),
 					resource.TestCheckResourceAttr(name, "networks.0.network", "2.5.6.200/32"),
+					resource.TestCheckResourceAttrSet(name, "network_ids.2.5.6.200/32"),
 					resource.TestCheckResourceAttr(name, "endpoints.#", "1"),
 					resource.TestCheckResourceAttr(name, "endpoints.0.ipv4.0.enabled", "true"),
 					resource.TestCheckResourceAttr(name, "endpoints.0.ipv4.0.authentication_enabled", "true"),
@@ -61,6 +70,451 @@ func TestAccCloudflareTeamsLocationBasic(t *testing.T) {
 	})
 }
 
+func TestAccCloudflareTeamsLocationAnonymizedLogsDefault(t *testing.T) {
+	// Temporarily unset CLOUDFLARE_API_TOKEN if it is set as the Access
+	// service does not yet support the API tokens and it results in
+	// misleading state error messages.
+	if os.Getenv("CLOUDFLARE_API_TOKEN") != "" {
+		t.Setenv("CLOUDFLARE_API_TOKEN", "")
+	}
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_dns_location.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareTeamsLocationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareTeamsLocationConfigMinimal(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "anonymized_logs_enabled", "false"),
+				),
+			},
+			{
+				Config: testAccCloudflareTeamsLocationConfigMinimal(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudflareTeamsLocationECSSupportUpdate(t *testing.T) {
+	// Temporarily unset CLOUDFLARE_API_TOKEN if it is set as the Access
+	// service does not yet support the API tokens and it results in
+	// misleading state error messages.
+	if os.Getenv("CLOUDFLARE_API_TOKEN") != "" {
+		t.Setenv("CLOUDFLARE_API_TOKEN", "")
+	}
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_dns_location.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareTeamsLocationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareTeamsLocationConfigECSSupport(rnd, accountID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "ecs_support", "false"),
+					testAccCheckCloudflareTeamsLocationECSSupport(name, false),
+				),
+			},
+			{
+				Config: testAccCloudflareTeamsLocationConfigECSSupport(rnd, accountID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "ecs_support", "true"),
+					testAccCheckCloudflareTeamsLocationECSSupport(name, true),
+				),
+			},
+			{
+				Config: testAccCloudflareTeamsLocationConfigECSSupport(rnd, accountID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "ecs_support", "false"),
+					testAccCheckCloudflareTeamsLocationECSSupport(name, false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareTeamsLocationImportDNSDestinations(t *testing.T) {
+	// Temporarily unset CLOUDFLARE_API_TOKEN if it is set as the Access
+	// service does not yet support the API tokens and it results in
+	// misleading state error messages.
+	if os.Getenv("CLOUDFLARE_API_TOKEN") != "" {
+		t.Setenv("CLOUDFLARE_API_TOKEN", "")
+	}
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_dns_location.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareTeamsLocationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareTeamsLocationConfigMinimal(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, consts.AccountIDSchemaKey, accountID),
+					resource.TestCheckResourceAttrSet(name, "dns_destination_ips_id"),
+				),
+			},
+			{
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStatePersist:  true,
+				ResourceName:        name,
+				ImportStateIdPrefix: fmt.Sprintf("%s/", accountID),
+			},
+			{
+				Config: testAccCloudflareTeamsLocationConfigMinimal(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestAccCloudflareTeamsLocationImportByName(t *testing.T) {
+	// Temporarily unset CLOUDFLARE_API_TOKEN if it is set as the Access
+	// service does not yet support the API tokens and it results in
+	// misleading state error messages.
+	if os.Getenv("CLOUDFLARE_API_TOKEN") != "" {
+		t.Setenv("CLOUDFLARE_API_TOKEN", "")
+	}
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_dns_location.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareTeamsLocationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareTeamsLocationConfigMinimal(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+				),
+			},
+			{
+				ImportState:       true,
+				ImportStateVerify: true,
+				ResourceName:      name,
+				ImportStateId:     fmt.Sprintf("%s/name:%s", accountID, rnd),
+			},
+		},
+	})
+}
+
+func TestTeamsLocationImportByName(t *testing.T) {
+	t.Parallel()
+
+	client := newMockCloudflareClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/accounts/account-id/gateway/locations" {
+			fmt.Fprint(w, `{"success": true, "errors": [], "messages": [], "result": {"id": "location-1", "name": "office", "endpoints": {"ipv4": {"enabled": true}}}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{
+			"success": true,
+			"errors": [],
+			"messages": [],
+			"result": [
+				{"id": "location-1", "name": "office"},
+				{"id": "location-2", "name": "branch"},
+				{"id": "location-3", "name": "branch"}
+			],
+			"result_info": {"page": 1, "per_page": 20, "count": 3, "total_count": 3}
+		}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceCloudflareTeamsLocationSchema(), map[string]interface{}{})
+	d.SetId("account-id/name:office")
+	imported, err := resourceCloudflareTeamsLocationImport(context.Background(), d, client)
+	assert.NoError(t, err)
+	assert.Equal(t, "location-1", imported[0].Id())
+	assert.Equal(t, "account-id", imported[0].Get(consts.AccountIDSchemaKey))
+
+	d = schema.TestResourceDataRaw(t, resourceCloudflareTeamsLocationSchema(), map[string]interface{}{})
+	d.SetId("account-id/name:branch")
+	_, err = resourceCloudflareTeamsLocationImport(context.Background(), d, client)
+	assert.ErrorContains(t, err, `2 Teams Locations named "branch"`)
+
+	d = schema.TestResourceDataRaw(t, resourceCloudflareTeamsLocationSchema(), map[string]interface{}{})
+	d.SetId("account-id/name:missing")
+	_, err = resourceCloudflareTeamsLocationImport(context.Background(), d, client)
+	assert.ErrorContains(t, err, `no Teams Location named "missing"`)
+}
+
+func TestAccCloudflareTeamsLocationDuplicateEndpointNetworks(t *testing.T) {
+	// Temporarily unset CLOUDFLARE_API_TOKEN if it is set as the Access
+	// service does not yet support the API tokens and it results in
+	// misleading state error messages.
+	if os.Getenv("CLOUDFLARE_API_TOKEN") != "" {
+		t.Setenv("CLOUDFLARE_API_TOKEN", "")
+	}
+
+	rnd := generateRandomResourceName()
+	name := fmt.Sprintf("cloudflare_zero_trust_dns_location.%s", rnd)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testAccCheckCloudflareTeamsLocationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCloudflareTeamsLocationConfigDuplicateEndpointNetworks(rnd, accountID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "endpoints.0.doh.0.networks.#", "2"),
+				),
+			},
+			{
+				Config: testAccCloudflareTeamsLocationConfigDuplicateEndpointNetworks(rnd, accountID),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+func TestInflateTeamsLocationNetworksFromListDedupes(t *testing.T) {
+	t.Parallel()
+
+	networks, err := inflateTeamsLocationNetworksFromList([]interface{}{
+		map[string]interface{}{"network": "2.5.6.202/32"},
+		map[string]interface{}{"network": "3.5.6.203/32"},
+		map[string]interface{}{"network": "2.5.6.202/32"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []cloudflare.TeamsLocationNetwork{{Network: "2.5.6.202/32"}, {Network: "3.5.6.203/32"}}, networks)
+}
+
+func TestTeamsLocationDuplicateEndpointNetworksWarning(t *testing.T) {
+	t.Parallel()
+
+	network := func(cidr string) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"network": cty.StringVal(cidr)})
+	}
+	endpoints := func(dohNetworks cty.Value) map[string]cty.Value {
+		return map[string]cty.Value{
+			"endpoints": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"ipv6": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"enabled":  cty.True,
+					"networks": cty.ListVal([]cty.Value{network("2a09:bac5:50c3:400::6b:57/128")}),
+				})}),
+				"doh": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+					"enabled":  cty.True,
+					"networks": dohNetworks,
+				})}),
+			})}),
+		}
+	}
+	networkType := cty.List(cty.Object(map[string]cty.Type{"network": cty.String}))
+
+	resp := &schema.ValidateResourceConfigFuncResponse{}
+	warnTeamsLocationDuplicateEndpointNetworks(context.Background(), schema.ValidateResourceConfigFuncRequest{
+		RawConfig: testRawResourceConfig(resourceCloudflareZeroTrustDNSLocation(), endpoints(cty.ListVal([]cty.Value{network("2.5.6.202/32"), network("2.5.6.202/32")}))),
+	}, resp)
+	assert.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, diag.Warning, resp.Diagnostics[0].Severity)
+	assert.Contains(t, resp.Diagnostics[0].Summary, "endpoints.0.doh.0.networks lists 2.5.6.202/32 more than once")
+
+	for _, dohNetworks := range []cty.Value{cty.NullVal(networkType), cty.UnknownVal(networkType)} {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		warnTeamsLocationDuplicateEndpointNetworks(context.Background(), schema.ValidateResourceConfigFuncRequest{
+			RawConfig: testRawResourceConfig(resourceCloudflareZeroTrustDNSLocation(), endpoints(dohNetworks)),
+		}, resp)
+		assert.Empty(t, resp.Diagnostics, "doh networks %#v", dohNetworks)
+	}
+}
+
+func TestPreserveTeamsLocationEndpointNetworkDuplicates(t *testing.T) {
+	t.Parallel()
+
+	priorNetworks := []interface{}{
+		map[string]interface{}{"network": "2.5.6.202/32"},
+		map[string]interface{}{"network": "2.5.6.202/32"},
+	}
+	prior := []interface{}{map[string]interface{}{
+		"doh": []interface{}{map[string]interface{}{"networks": priorNetworks}},
+		"dot": []interface{}{map[string]interface{}{"networks": priorNetworks}},
+	}}
+
+	endpoints := flattenTeamsEndpoints(&cloudflare.TeamsLocationEndpoints{
+		DohEndpoint: cloudflare.TeamsLocationDohEndpointFields{TeamsLocationEndpointFields: cloudflare.TeamsLocationEndpointFields{
+			Networks: []cloudflare.TeamsLocationNetwork{{Network: "2.5.6.202/32"}},
+		}},
+		DotEndpoint: cloudflare.TeamsLocationDotEndpointFields{TeamsLocationEndpointFields: cloudflare.TeamsLocationEndpointFields{
+			Networks: []cloudflare.TeamsLocationNetwork{{Network: "2.5.6.201/32"}},
+		}},
+	})
+	preserveTeamsLocationEndpointNetworkDuplicates(endpoints, prior)
+
+	current := endpoints[0].(map[string]interface{})
+	assert.Equal(t, priorNetworks, current["doh"].([]map[string]interface{})[0]["networks"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"network": "2.5.6.201/32"}}, current["dot"].([]map[string]interface{})[0]["networks"])
+}
+
+func TestAccCloudflareTeamsLocationIncompleteEndpoints(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareTeamsLocationConfigIncompleteEndpoints(rnd, accountID),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`Insufficient ipv6 blocks`)),
+			},
+		},
+	})
+}
+
+func TestAccCloudflareTeamsLocationClientDefaultWithoutEndpoints(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCloudflareTeamsLocationConfigClientDefaultWithoutEndpoints(rnd, accountID),
+				ExpectError: regexp.MustCompile(regexp.QuoteMeta(`endpoints must be configured when client_default is true`)),
+			},
+		},
+	})
+}
+
+func TestTeamsLocationClientDefaultEndpointsError(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		clientDefault bool
+		hasEndpoints  bool
+		ipv4Enabled   bool
+		expectError   bool
+	}{
+		{true, false, false, true},
+		{true, true, false, true},
+		{true, true, true, false},
+		{false, false, false, false},
+		{false, true, false, false},
+	}
+
+	for _, c := range cases {
+		err := teamsLocationClientDefaultEndpointsError(c.clientDefault, c.hasEndpoints, c.ipv4Enabled)
+		assert.Equal(t, c.expectError, err != nil, "client_default %t, endpoints %t, ipv4 %t", c.clientDefault, c.hasEndpoints, c.ipv4Enabled)
+	}
+}
+
+func TestFlattenTeamsLocationNetworkIDs(t *testing.T) {
+	t.Parallel()
+
+	networkIDs := flattenTeamsLocationNetworkIDs([]cloudflare.TeamsLocationNetwork{
+		{ID: "network-1", Network: "2.5.6.200/32"},
+		{ID: "network-2", Network: "2.5.6.201/32"},
+		{Network: "2.5.6.202/32"},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"2.5.6.200/32": "network-1",
+		"2.5.6.201/32": "network-2",
+	}, networkIDs)
+}
+
+func TestTeamsLocationECSSupportWithoutIPv4EndpointWarning(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		ecsSupport    bool
+		ipv4Enabled   bool
+		expectWarning bool
+	}{
+		{true, false, true},
+		{true, true, false},
+		{false, false, false},
+		{false, true, false},
+	}
+
+	for _, c := range cases {
+		warning := teamsLocationECSSupportWithoutIPv4EndpointWarning(c.ecsSupport, c.ipv4Enabled)
+		assert.Equal(t, c.expectWarning, warning != "", "ecs_support %t, ipv4 %t", c.ecsSupport, c.ipv4Enabled)
+	}
+
+	config := func(ipv4Enabled cty.Value) cty.Value {
+		return testRawResourceConfig(resourceCloudflareZeroTrustDNSLocation(), map[string]cty.Value{
+			"ecs_support": cty.True,
+			"endpoints": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+				"ipv4": cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"enabled": ipv4Enabled})}),
+			})}),
+		})
+	}
+
+	resp := &schema.ValidateResourceConfigFuncResponse{}
+	warnTeamsLocationECSSupportWithoutIPv4Endpoint(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: config(cty.False)}, resp)
+	assert.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, diag.Warning, resp.Diagnostics[0].Severity)
+	assert.Equal(t, cty.GetAttrPath("ecs_support"), resp.Diagnostics[0].AttributePath)
+
+	resp = &schema.ValidateResourceConfigFuncResponse{}
+	warnTeamsLocationECSSupportWithoutIPv4Endpoint(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: config(cty.UnknownVal(cty.Bool))}, resp)
+	assert.Empty(t, resp.Diagnostics)
+}
+
+func TestTeamsLocationIPv6EndpointNetworksRejectIPv4(t *testing.T) {
+	t.Parallel()
+
+	config := func(network string) *tfsdkv2.ResourceConfig {
+		endpoint := map[string]interface{}{"enabled": true}
+		return tfsdkv2.NewResourceConfigRaw(map[string]interface{}{
+			consts.AccountIDSchemaKey: "identifier",
+			"name":                    "example",
+			"endpoints": []interface{}{map[string]interface{}{
+				"ipv4": []interface{}{endpoint},
+				"ipv6": []interface{}{map[string]interface{}{
+					"enabled":  true,
+					"networks": []interface{}{map[string]interface{}{"network": network}},
+				}},
+				"doh": []interface{}{endpoint},
+				"dot": []interface{}{endpoint},
+			}},
+		})
+	}
+
+	diags := resourceCloudflareZeroTrustDNSLocation().Validate(config("2a09:bac5:50c3:400::6b:57/128"))
+	assert.False(t, diags.HasError(), "%v", diags)
+
+	diags = resourceCloudflareZeroTrustDNSLocation().Validate(config("192.0.2.0/24"))
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "must be an IPv6 CIDR, got the IPv4 CIDR")
+}
+
 func testAccCloudflareTeamsLocationConfigBasic(rnd, accountID string) string {
 	return fmt.Sprintf(`
 resource "cloudflare_zero_trust_dns_location" "%[1]s" {
@@ -91,6 +545,122 @@ resource "cloudflare_zero_trust_dns_location" "%[1]s" {
 `, rnd, accountID)
 }
 
+func testAccCloudflareTeamsLocationConfigDuplicateEndpointNetworks(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_dns_location" "%[1]s" {
+  name       = "%[1]s"
+  account_id = "%[2]s"
+
+  endpoints {
+    ipv4 {
+      enabled = true
+    }
+    ipv6 {
+      enabled = true
+    }
+    dot {
+      enabled = true
+    }
+    doh {
+      enabled  = true
+      networks = [{ network = "2.5.6.202/32" }, { network = "2.5.6.202/32" }]
+    }
+  }
+}
+`, rnd, accountID)
+}
+
+func testAccCloudflareTeamsLocationConfigMinimal(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_dns_location" "%[1]s" {
+  name       = "%[1]s"
+  account_id = "%[2]s"
+
+  endpoints {
+    ipv4 {
+      enabled = true
+    }
+    ipv6 {
+      enabled = true
+    }
+    dot {
+      enabled = true
+    }
+    doh {
+      enabled = true
+    }
+  }
+}
+`, rnd, accountID)
+}
+
+func testAccCloudflareTeamsLocationConfigIncompleteEndpoints(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_dns_location" "%[1]s" {
+  name       = "%[1]s"
+  account_id = "%[2]s"
+
+  endpoints {
+    ipv4 {
+      enabled = true
+    }
+    dot {
+      enabled = true
+    }
+    doh {
+      enabled = true
+    }
+  }
+}
+`, rnd, accountID)
+}
+
+func testAccCloudflareTeamsLocationConfigECSSupport(rnd, accountID string, ecsSupport bool) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_dns_location" "%[1]s" {
+  name        = "%[1]s"
+  account_id  = "%[2]s"
+  ecs_support = %[3]t
+
+  endpoints {
+    ipv4 {
+      enabled = true
+    }
+    ipv6 {
+      enabled = true
+    }
+    dot {
+      enabled = true
+    }
+    doh {
+      enabled = true
+    }
+  }
+}
+`, rnd, accountID, ecsSupport)
+}
+
+func testAccCheckCloudflareTeamsLocationECSSupport(name string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("not found: %s", name)
+		}
+
+		client := testAccProvider.Meta().(*cloudflare.API)
+		location, err := client.TeamsLocation(context.Background(), rs.Primary.Attributes[consts.AccountIDSchemaKey], rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error fetching Teams Location %q: %w", rs.Primary.ID, err)
+		}
+
+		if location.ECSSupport == nil || *location.ECSSupport != expected {
+			return fmt.Errorf("expected ecs_support to be %t in the API, got %t", expected, cloudflare.Bool(location.ECSSupport))
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckCloudflareTeamsLocationDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*cloudflare.API)
 
@@ -107,3 +677,13 @@ func testAccCheckCloudflareTeamsLocationDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func testAccCloudflareTeamsLocationConfigClientDefaultWithoutEndpoints(rnd, accountID string) string {
+	return fmt.Sprintf(`
+resource "cloudflare_zero_trust_dns_location" "%[1]s" {
+  name           = "%[1]s"
+  account_id     = "%[2]s"
+  client_default = true
+}
+`, rnd, accountID)
+}
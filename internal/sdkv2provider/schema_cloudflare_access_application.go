@@ -2,7 +2,10 @@ package sdkv2provider
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/cloudflare/cloudflare-go"
@@ -15,8 +18,21 @@ import (
 const (
 	saasAuthTypeOIDC = "oidc"
 	saasAuthTypeSAML = "saml"
+
+	saasGrantTypeRefreshTokens = "refresh_tokens"
+	saasGrantTypeHybrid        = "hybrid"
+	saasGrantTypeImplicit      = "implicit"
+	saasGrantTypePKCE          = "authorization_code_with_pkce"
 )
 
+// accessApplicationCORSMethods are the HTTP methods accepted in
+// cors_headers.allowed_methods.
+var accessApplicationCORSMethods = []string{"GET", "POST", "HEAD", "PUT", "DELETE", "CONNECT", "OPTIONS", "TRACE", "PATCH"}
+
+// accessApplicationMaxTags is the number of tags Cloudflare accepts on a
+// single Access Application.
+const accessApplicationMaxTags = 10
+
 func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		consts.AccountIDSchemaKey: {
@@ -38,6 +54,16 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Application Audience (AUD) Tag of the application.",
 		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The RFC3339 timestamp of when the application was created.",
+		},
+		"updated_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The RFC3339 timestamp of when the application was last modified.",
+		},
 		"name": {
 			Type:        schema.TypeString,
 			Computed:    true,
@@ -52,7 +78,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 			DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
 				appType := d.Get("type").(string)
 				// Suppress the diff if it's an app type that doesn't need a `domain` value.
-				if appType == "infrastructure" {
+				if appType == "app_launcher" || appType == "infrastructure" || appType == "warp" {
 					return true
 				}
 
@@ -68,7 +94,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 			DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
 				appType := d.Get("type").(string)
 				// Suppress the diff if it's an app type that doesn't need a `domain` value.
-				if appType == "infrastructure" {
+				if appType == "app_launcher" || appType == "infrastructure" || appType == "warp" {
 					return true
 				}
 
@@ -105,7 +131,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 				Type: schema.TypeString,
 			},
 			Description: "List of public domains secured by Access. Only present for self_hosted, vnc, and ssh applications. Always includes the value set as `domain`. Deprecated in favor of `destinations` and will be removed in the next major version.",
-			Deprecated:  "Use `destinations` instead",
+			Deprecated:  "`self_hosted_domains` is deprecated and will be removed in the next major version, migrate each domain to a `destinations` block with `uri` set to the domain. See https://registry.terraform.io/providers/cloudflare/cloudflare/latest/docs/resources/zero_trust_access_application#destinations.",
 		},
 		"type": {
 			Type:         schema.TypeString,
@@ -135,7 +161,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 					return true
 				}
 
-				return oldValue == newValue
+				return suppressEquivalentDurations(k, oldValue, newValue, d)
 			},
 			ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
 				v := val.(string)
@@ -157,17 +183,19 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 						Type:     schema.TypeSet,
 						Optional: true,
 						Elem: &schema.Schema{
-							Type: schema.TypeString,
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringInSlice(accessApplicationCORSMethods, false),
 						},
-						Description: "List of methods to expose via CORS.",
+						Description: fmt.Sprintf("List of methods to expose via CORS. %s", renderAvailableDocumentationValuesStringSlice(accessApplicationCORSMethods)),
 					},
 					"allowed_origins": {
 						Type:     schema.TypeSet,
 						Optional: true,
 						Elem: &schema.Schema{
-							Type: schema.TypeString,
+							Type:         schema.TypeString,
+							ValidateFunc: validateCORSAllowedOrigin,
 						},
-						Description: "List of origins permitted to make CORS requests.",
+						Description: "List of origins permitted to make CORS requests. Each origin is `*`, a scheme and host such as `https://example.com`, or a single-level wildcard subdomain such as `https://*.example.com`.",
 					},
 					"allowed_headers": {
 						Type:     schema.TypeSet,
@@ -200,7 +228,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 					"max_age": {
 						Type:         schema.TypeInt,
 						Optional:     true,
-						ValidateFunc: validation.IntBetween(-1, 86400),
+						ValidateFunc: validateCORSMaxAge,
 						Description:  "The maximum time a preflight request will be cached.",
 					},
 				},
@@ -217,9 +245,14 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 					"auth_type": {
 						Type:         schema.TypeString,
 						Optional:     true,
-						ValidateFunc: validation.StringInSlice([]string{"oidc", "saml"}, false),
-						Description:  "",
-						ForceNew:     true,
+						Computed:     true,
+						ValidateFunc: validation.StringInSlice([]string{"oidc", "saml"}, true),
+						// The API always returns the authentication type in lowercase.
+						DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+							return strings.EqualFold(oldValue, newValue)
+						},
+						Description: "",
+						ForceNew:    true,
 					},
 					"public_key": {
 						Type:        schema.TypeString,
@@ -239,6 +272,11 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 						Description: "The application client secret, only returned on initial apply",
 						Sensitive:   true,
 					},
+					"oidc_discovery_url": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The OpenID Connect discovery endpoint of the application. Only populated for OIDC applications.",
+					},
 					"redirect_uris": {
 						Type:     schema.TypeSet,
 						Optional: true,
@@ -276,9 +314,10 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 						Description: "A regex to filter Cloudflare groups returned in ID token and userinfo endpoint",
 					},
 					"access_token_lifetime": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Description: "The lifetime of the Access Token after creation. Valid units are `m` and `h`. Must be greater than or equal to 1m and less than or equal to 24h.",
+						Type:             schema.TypeString,
+						Optional:         true,
+						DiffSuppressFunc: suppressEquivalentDurations,
+						Description:      "The lifetime of the Access Token after creation. Valid units are `m` and `h`. Must be greater than or equal to 1m and less than or equal to 24h.",
 					},
 					"allow_pkce_without_client_secret": {
 						Type:        schema.TypeBool,
@@ -318,6 +357,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 								"required": {
 									Type:        schema.TypeBool,
 									Optional:    true,
+									Default:     false,
 									Description: "True if the attribute must be always present.",
 								},
 								"source": {
@@ -332,10 +372,11 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 												Description: "The name of the attribute as provided by the IDP.",
 											},
 											"name_by_idp": {
-												Type:        schema.TypeMap,
-												Optional:    true,
-												Description: "A mapping from IdP ID to claim name.",
-												Elem:        &schema.Schema{Type: schema.TypeString},
+												Type:             schema.TypeMap,
+												Optional:         true,
+												Description:      "A mapping from IdP ID to claim name.",
+												Elem:             &schema.Schema{Type: schema.TypeString},
+												DiffSuppressFunc: suppressNullOrEmptyMap,
 											},
 										},
 									},
@@ -366,14 +407,16 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 
 					// SAML options
 					"sp_entity_id": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Description: "A globally unique name for an identity or service provider.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validateSPEntityID,
+						Description:  "A globally unique name for an identity or service provider.",
 					},
 					"consumer_service_url": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Description: "The service provider's endpoint that is responsible for receiving and parsing a SAML assertion.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validateHTTPURLPreferHTTPS,
+						Description:  "The service provider's endpoint that is responsible for receiving and parsing a SAML assertion.",
 					},
 					"name_id_format": {
 						Type:         schema.TypeString,
@@ -393,9 +436,10 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 									Description: "The name of the attribute as provided to the SaaS app.",
 								},
 								"name_format": {
-									Type:        schema.TypeString,
-									Optional:    true,
-									Description: "A globally unique name for an identity or service provider.",
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: validateSAMLAttributeNameFormat,
+									Description:  "A globally unique name for an identity or service provider.",
 								},
 								"friendly_name": {
 									Type:        schema.TypeString,
@@ -419,10 +463,11 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 												Description: "The name of the attribute as provided by the IDP.",
 											},
 											"name_by_idp": {
-												Type:        schema.TypeMap,
-												Optional:    true,
-												Description: "A mapping from IdP ID to claim name.",
-												Elem:        &schema.Schema{Type: schema.TypeString},
+												Type:             schema.TypeMap,
+												Optional:         true,
+												Description:      "A mapping from IdP ID to claim name.",
+												Elem:             &schema.Schema{Type: schema.TypeString},
+												DiffSuppressFunc: suppressNullOrEmptyMap,
 											},
 										},
 									},
@@ -436,9 +481,12 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 						Description: "The unique identifier for the SaaS application.",
 					},
 					"sso_endpoint": {
-						Type:        schema.TypeString,
-						Computed:    true,
-						Description: "The endpoint where the SaaS application will send login requests.",
+						Type:             schema.TypeString,
+						Optional:         true,
+						Computed:         true,
+						ValidateFunc:     validateHTTPSURL,
+						DiffSuppressFunc: suppressUnsetSSOEndpoint,
+						Description:      "The endpoint where the SaaS application will send login requests. Defaults to the endpoint assigned by Cloudflare.",
 					},
 					"default_relay_state": {
 						Type:        schema.TypeString,
@@ -446,9 +494,10 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 						Description: "The relay state used if not provided by the identity provider.",
 					},
 					"name_id_transform_jsonata": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Description: "A [JSONata](https://jsonata.org/) expression that transforms an application's user identities into a NameID value for its SAML assertion. This expression should evaluate to a singular string. The output of this expression can override the `name_id_format` setting.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validateNameIDTransformJsonata,
+						Description:  "A [JSONata](https://jsonata.org/) expression that transforms an application's user identities into a NameID value for its SAML assertion. This expression should evaluate to a singular string. The output of this expression can override the `name_id_format` setting.",
 					},
 					"saml_attribute_transform_jsonata": {
 						Type:        schema.TypeString,
@@ -475,8 +524,9 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 						Description: "The communication protocol your application secures.",
 					},
 					"target_attributes": {
-						Type:        schema.TypeList,
+						Type:        schema.TypeSet,
 						Required:    true,
+						Set:         HashByMapKey("name"),
 						Description: "Contains a map of target attribute keys to target attribute values.",
 						Elem: &schema.Resource{
 							Schema: map[string]*schema.Schema{
@@ -488,6 +538,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 								"values": {
 									Type:        schema.TypeList,
 									Required:    true,
+									MinItems:    1,
 									Description: "The values of the attribute.",
 									Elem: &schema.Schema{
 										Type: schema.TypeString,
@@ -517,7 +568,15 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
-			Description: "The identity providers selected for the application.",
+			Description: "The identity providers selected for the application. Use `[\"*\"]` to allow every identity provider of the account or zone, resolved when the application is applied.",
+		},
+		"resolved_allowed_idps": {
+			Type:     schema.TypeSet,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "The identity providers attached to the application, with `*` in `allowed_idps` resolved to their IDs.",
 		},
 		"custom_deny_message": {
 			Type:        schema.TypeString,
@@ -525,14 +584,16 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 			Description: "Option that returns a custom error message when a user is denied access to the application.",
 		},
 		"custom_deny_url": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Option that redirects to a custom URL when a user is denied access to the application via identity based rules.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateHTTPURL,
+			Description:  "Option that redirects to a custom URL when a user is denied access to the application via identity based rules.",
 		},
 		"custom_non_identity_deny_url": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "Option that redirects to a custom URL when a user is denied access to the application via non identity rules.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateHTTPURL,
+			Description:  "Option that redirects to a custom URL when a user is denied access to the application via non identity rules.",
 		},
 		"http_only_cookie_attribute": {
 			Type:        schema.TypeBool,
@@ -550,6 +611,27 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "Image URL for the logo shown in the app launcher dashboard.",
 		},
+		"bookmark_app": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Bookmark specific settings, only applicable when `type` is `bookmark`. Replaces the top level `domain` and `logo_url` attributes.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"url": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateHTTPURL,
+						Description:  "The URL the bookmark opens in the App Launcher.",
+					},
+					"logo_url": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Image URL for the logo shown in the App Launcher.",
+					},
+				},
+			},
+		},
 		"skip_interstitial": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -592,7 +674,13 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
-			Description: "The itags associated with the application.",
+			Description: fmt.Sprintf("The itags associated with the application. Cloudflare allows at most %d tags per application.", accessApplicationMaxTags),
+		},
+		"create_missing_tags": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to create any tags in `tags` that do not exist yet before attaching them to the application.",
 		},
 		"app_launcher_logo_url": {
 			Type:        schema.TypeString,
@@ -600,14 +688,16 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 			Description: "The logo URL of the app launcher.",
 		},
 		"header_bg_color": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "The background color of the header bar in the app launcher.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressAppLauncherCustomization,
+			Description:      "The background color of the header bar in the app launcher.",
 		},
 		"bg_color": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "The background color of the app launcher.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressAppLauncherCustomization,
+			Description:      "The background color of the app launcher.",
 		},
 		"footer_links": {
 			Type:     schema.TypeSet,
@@ -620,19 +710,21 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 						Description: "The name of the footer link.",
 					},
 					"url": {
-						Type:        schema.TypeString,
-						Optional:    true,
-						Description: "The URL of the footer link.",
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validateHTTPURL,
+						Description:  "The URL of the footer link. Requires `name` to be set.",
 					},
 				},
 			},
 			Description: "The footer links of the app launcher.",
 		},
 		"landing_page_design": {
-			Type:        schema.TypeList,
-			Optional:    true,
-			MaxItems:    1,
-			Description: "The landing page design of the app launcher.",
+			Type:             schema.TypeList,
+			Optional:         true,
+			MaxItems:         1,
+			DiffSuppressFunc: suppressAppLauncherCustomization,
+			Description:      "The landing page design of the app launcher.",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
 					"title": {
@@ -690,12 +782,14 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 					"enabled": {
 						Type:        schema.TypeBool,
 						Optional:    true,
+						Default:     false,
 						Description: "Whether SCIM provisioning is turned on for this application.",
 					},
 					"remote_uri": {
-						Type:        schema.TypeString,
-						Required:    true,
-						Description: "The base URI for the application's SCIM-compatible API.",
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateHTTPSURL,
+						Description:  "The base URI for the application's SCIM-compatible API.",
 					},
 					"idp_uid": {
 						Type:        schema.TypeString,
@@ -705,6 +799,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 					"deactivate_on_delete": {
 						Type:        schema.TypeBool,
 						Optional:    true,
+						Computed:    true,
 						Description: "If false, propagates DELETE requests to the target application for SCIM resources. If true, sets 'active' to false on the SCIM resource. Note: Some targets do not support DELETE operations.",
 					},
 					"authentication": {
@@ -727,8 +822,9 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 									Description: "User name used to authenticate with the remote SCIM service.",
 								},
 								"password": {
-									Type:     schema.TypeString,
-									Optional: true,
+									Type:      schema.TypeString,
+									Optional:  true,
+									Sensitive: true,
 									StateFunc: func(val interface{}) string {
 										return CONCEALED_STRING
 									},
@@ -737,6 +833,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 								"token": {
 									Type:        schema.TypeString,
 									Optional:    true,
+									Sensitive:   true,
 									Description: "Token used to authenticate with the remote SCIM service.",
 									StateFunc: func(val interface{}) string {
 										return CONCEALED_STRING
@@ -751,6 +848,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 								"client_secret": {
 									Type:        schema.TypeString,
 									Optional:    true,
+									Sensitive:   true,
 									Description: "Secret used to authenticate when generating a token for authenticating with the remove SCIM service.",
 									StateFunc: func(val interface{}) string {
 										return CONCEALED_STRING
@@ -778,8 +876,9 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 						},
 					},
 					"mappings": {
-						Type:        schema.TypeList,
+						Type:        schema.TypeSet,
 						Optional:    true,
+						Set:         hashAccessApplicationSCIMMapping,
 						Description: "A list of mappings to apply to SCIM resources before provisioning them in this application. These can transform or filter the resources to be provisioned.",
 						Elem: &schema.Resource{
 							Schema: map[string]*schema.Schema{
@@ -792,6 +891,7 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 								"enabled": {
 									Type:        schema.TypeBool,
 									Optional:    true,
+									Computed:    true,
 									Description: "Whether or not this mapping is enabled.",
 								},
 								"filter": {
@@ -830,9 +930,10 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 									},
 								},
 								"strictness": {
-									Type:        schema.TypeString,
-									Optional:    true,
-									Description: "How strictly to adhere to outbound resource schemas when provisioning to this mapping. \"strict\" will remove unknown values when provisioning, while \"passthrough\" will pass unknown values to the target.",
+									Type:         schema.TypeString,
+									Optional:     true,
+									ValidateFunc: validation.StringInSlice([]string{"strict", "passthrough"}, false),
+									Description:  "How strictly to adhere to outbound resource schemas when provisioning to this mapping. \"strict\" will remove unknown values when provisioning, while \"passthrough\" will pass unknown values to the target.",
 								},
 							},
 						},
@@ -843,6 +944,165 @@ func resourceCloudflareAccessApplicationSchema() map[string]*schema.Schema {
 	}
 }
 
+// suppressAppLauncherCustomization suppresses diffs on App Launcher styling
+// and landing page design for application types other than `app_launcher`
+// which do not use them.
+func suppressAppLauncherCustomization(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return d.Get("type").(string) != "app_launcher"
+}
+
+// suppressNullOrEmptyMap suppresses the diff between a map the API returned
+// as null and one configured as `{}`, which both hold no entries.
+func suppressNullOrEmptyMap(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if !strings.HasSuffix(k, ".%") {
+		return false
+	}
+
+	return (oldValue == "" || oldValue == "0") && (newValue == "" || newValue == "0")
+}
+
+// suppressUnsetSSOEndpoint suppresses the diff when sso_endpoint is not
+// configured, the endpoint assigned by Cloudflare is kept.
+func suppressUnsetSSOEndpoint(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return newValue == ""
+}
+
+// hashAccessApplicationSCIMMapping identifies SCIM mappings by the resource
+// schema and filter they apply to as the API does not preserve their order.
+func hashAccessApplicationSCIMMapping(v interface{}) int {
+	m := v.(map[string]interface{})
+	schemaURN, _ := m["schema"].(string)
+	filter, _ := m["filter"].(string)
+
+	return hashCodeString(fmt.Sprintf("%s-%s", schemaURN, filter))
+}
+
+// suppressEquivalentDurations suppresses the diff when both values parse to
+// the same duration, such as `24h` and the canonical `24h0m0s` returned by
+// the API.
+func suppressEquivalentDurations(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if oldValue == newValue {
+		return true
+	}
+
+	oldDuration, err := time.ParseDuration(oldValue)
+	if err != nil {
+		return false
+	}
+	newDuration, err := time.ParseDuration(newValue)
+	if err != nil {
+		return false
+	}
+
+	return oldDuration == newDuration
+}
+
+// validateCORSMaxAge validates `Access-Control-Max-Age` values. -1 is the
+// documented way to disable preflight caching; 0 is accepted but browsers
+// interpret it inconsistently (some fall back to their own default of a few
+// seconds), so a warning is raised to steer towards -1.
+func validateCORSMaxAge(v interface{}, k string) (warnings []string, errs []error) {
+	warnings, errs = validation.IntBetween(-1, 86400)(v, k)
+	if len(errs) > 0 {
+		return warnings, errs
+	}
+
+	if v.(int) == 0 {
+		warnings = append(warnings, fmt.Sprintf("%q of 0 is not handled consistently by all browsers, use -1 to disable preflight caching instead", k))
+	}
+
+	return warnings, errs
+}
+
+// validateCORSAllowedOrigin accepts `*`, a scheme and host with an optional
+// port, or the same with a single leading wildcard label. Origins never carry
+// a path, query or fragment.
+func validateCORSAllowedOrigin(v interface{}, k string) (warnings []string, errs []error) {
+	origin := v.(string)
+	if origin == "*" {
+		return nil, nil
+	}
+
+	invalid := fmt.Errorf("%q must be \"*\" or an origin such as \"https://example.com\" or \"https://*.example.com\", got %q", k, origin)
+
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return nil, []error{invalid}
+	}
+	if u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return nil, []error{invalid}
+	}
+
+	host := strings.TrimPrefix(u.Hostname(), "*.")
+	if host == "" || strings.Contains(host, "*") || (host != u.Hostname() && !strings.Contains(host, ".")) {
+		return nil, []error{invalid}
+	}
+
+	return nil, nil
+}
+
+// validateNameIDTransformJsonata warns when a NameID transform is wrapped in
+// an array or object constructor. The expression must evaluate to a singular
+// string, anything else is only rejected when users sign in.
+func validateNameIDTransformJsonata(v interface{}, k string) (warnings []string, errs []error) {
+	expression := strings.TrimSpace(v.(string))
+	if expression == "" {
+		return
+	}
+
+	switch expression[0] {
+	case '[':
+		warnings = append(warnings, fmt.Sprintf("%q starts with an array constructor, the expression must evaluate to a singular string", k))
+	case '{':
+		warnings = append(warnings, fmt.Sprintf("%q starts with an object constructor, the expression must evaluate to a singular string", k))
+	}
+
+	return
+}
+
+var samlAttributeNameFormatRegexp = regexp.MustCompile(`^urn:oasis:names:tc:SAML:2\.0:attrname-format:(unspecified|basic|uri)$`)
+
+// validateSAMLAttributeNameFormat requires the SAML attribute name format to
+// be a URN and warns when it isn't one of the OASIS standard formats, which
+// leaves room for enterprise-specific formats.
+func validateSAMLAttributeNameFormat(v interface{}, k string) (warnings []string, errs []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	if !strings.HasPrefix(value, "urn:") {
+		errs = append(errs, fmt.Errorf("%q must be a URN beginning with \"urn:\", got: %q", k, value))
+		return
+	}
+
+	if !samlAttributeNameFormatRegexp.MatchString(value) {
+		warnings = append(warnings, fmt.Sprintf("%q is not a standard SAML attribute name format (urn:oasis:names:tc:SAML:2.0:attrname-format:unspecified, basic or uri), got: %q", k, value))
+	}
+	return
+}
+
+// validateSPEntityID warns when the SAML SP entity ID is neither a URN nor an
+// absolute URL. Entity IDs must be globally unique and Access rejects an
+// application reusing one, which is much less likely with a qualified name.
+func validateSPEntityID(v interface{}, k string) (warnings []string, errs []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	if strings.HasPrefix(strings.ToLower(value), "urn:") {
+		return
+	}
+
+	if u, err := url.Parse(value); err == nil && u.Scheme != "" && u.Host != "" {
+		return
+	}
+
+	warnings = append(warnings, fmt.Sprintf("%q should be a URN or an absolute URL to be globally unique, got: %q", k, value))
+	return
+}
+
 func convertCORSSchemaToStruct(d *schema.ResourceData) (*cloudflare.AccessApplicationCorsHeaders, error) {
 	CORSConfig := cloudflare.AccessApplicationCorsHeaders{}
 
@@ -1009,6 +1269,9 @@ func convertSaasSAMLSchemaToStruct(d *schema.ResourceData) *cloudflare.SaasAppli
 	samlConfig.DefaultRelayState = d.Get("saas_app.0.default_relay_state").(string)
 	samlConfig.NameIDTransformJsonata = d.Get("saas_app.0.name_id_transform_jsonata").(string)
 	samlConfig.SamlAttributeTransformJsonata = d.Get("saas_app.0.saml_attribute_transform_jsonata").(string)
+	if !getRawValue("saas_app.0.sso_endpoint", d.GetRawConfig()).IsNull() {
+		samlConfig.SSOEndpoint = d.Get("saas_app.0.sso_endpoint").(string)
+	}
 
 	customAttributes, _ := d.Get("saas_app.0.custom_attribute").([]interface{})
 	if len(customAttributes) != 0 {
@@ -1022,8 +1285,14 @@ func convertSaasSAMLSchemaToStruct(d *schema.ResourceData) *cloudflare.SaasAppli
 	return &samlConfig
 }
 
+// saasAuthType returns the configured SaaS authentication type in the
+// lowercase form used by the API, the attribute is case insensitive.
+func saasAuthType(d interface{ Get(string) interface{} }) string {
+	return strings.ToLower(d.Get("saas_app.0.auth_type").(string))
+}
+
 func convertSaasSchemaToStruct(d *schema.ResourceData) *cloudflare.SaasApplication {
-	if authType, _ := d.GetOk("saas_app.0.auth_type"); authType == "oidc" {
+	if saasAuthType(d) == saasAuthTypeOIDC {
 		return convertSaasOIDCSchemaToStruct(d)
 	} else {
 		return convertSaasSAMLSchemaToStruct(d)
@@ -1035,7 +1304,10 @@ func convertDestinationsToStruct(destinationPayloads []interface{}) ([]cloudflar
 	for i, dp := range destinationPayloads {
 		dpMap := dp.(map[string]interface{})
 
-		if dType, ok := dpMap["type"].(string); ok {
+		// The schema defaults type to public, default here as well so a
+		// payload without it never sends an empty type.
+		destinations[i].Type = cloudflare.AccessDestinationPublic
+		if dType, ok := dpMap["type"].(string); ok && dType != "" {
 			switch dType {
 			case "public":
 				destinations[i].Type = cloudflare.AccessDestinationPublic
@@ -1058,9 +1330,9 @@ func convertTargetContextsToStruct(d *schema.ResourceData) (*[]cloudflare.Access
 	TargetContexts := []cloudflare.AccessInfrastructureTargetContext{}
 	if value, ok := d.GetOk("target_criteria"); ok {
 		targetCriteria := value.([]interface{})
-		targetContext := cloudflare.AccessInfrastructureTargetContext{}
 		for _, item := range targetCriteria {
 			itemMap := item.(map[string]interface{})
+			targetContext := cloudflare.AccessInfrastructureTargetContext{}
 
 			if port, ok := itemMap["port"].(int); ok {
 				targetContext.Port = port
@@ -1076,21 +1348,19 @@ func convertTargetContextsToStruct(d *schema.ResourceData) (*[]cloudflare.Access
 				}
 			}
 
-			str_return := make(map[string][]string)
-			if sshVal, ok := itemMap["target_attributes"].([]interface{}); ok && len(sshVal) > 0 {
-				for _, attrItem := range sshVal {
+			if sshVal, ok := itemMap["target_attributes"].(*schema.Set); ok && sshVal.Len() > 0 {
+				attributes := make(map[string][]string)
+				for _, attrItem := range sshVal.List() {
 					if sshMap, ok := attrItem.(map[string]interface{}); ok {
-						attributes := make(map[string][]string)
 						key := sshMap["name"].(string)
 						if usernames, ok := sshMap["values"].([]interface{}); ok {
 							for _, username := range usernames {
 								attributes[key] = append(attributes[key], username.(string))
 							}
 						}
-						str_return = attributes
 					}
 				}
-				targetContext.TargetAttributes = str_return
+				targetContext.TargetAttributes = attributes
 			}
 
 			TargetContexts = append(TargetContexts, targetContext)
@@ -1134,16 +1404,24 @@ func convertSCIMConfigSchemaToStruct(d *schema.ResourceData) *cloudflare.AccessA
 		scimConfig.Enabled = cloudflare.BoolPtr(d.Get("scim_config.0.enabled").(bool))
 		scimConfig.RemoteURI = d.Get("scim_config.0.remote_uri").(string)
 		scimConfig.IdPUID = d.Get("scim_config.0.idp_uid").(string)
-		scimConfig.DeactivateOnDelete = cloudflare.BoolPtr(d.Get("scim_config.0.deactivate_on_delete").(bool))
+		// Leave deactivate_on_delete unset when it isn't configured so the
+		// API default applies instead of an implicit false.
+		if !getRawValue("scim_config.0.deactivate_on_delete", d.GetRawConfig()).IsNull() {
+			scimConfig.DeactivateOnDelete = cloudflare.BoolPtr(d.Get("scim_config.0.deactivate_on_delete").(bool))
+		}
 
 		if _, ok := d.GetOk("scim_config.0.authentication"); ok {
 			scimConfig.Authentication = convertScimConfigAuthenticationSchemaToStruct(d)
 		}
 
-		mappings := d.Get("scim_config.0.mappings").([]interface{})
+		mappings := d.Get("scim_config.0.mappings").(*schema.Set).List()
 
+		configuredEnabled := accessApplicationSCIMMappingsWithEnabled(d)
 		for _, mapping := range mappings {
 			mappingMap := mapping.(map[string]interface{})
+			if !configuredEnabled[hashAccessApplicationSCIMMapping(mappingMap)] {
+				delete(mappingMap, "enabled")
+			}
 			scimConfig.Mappings = append(scimConfig.Mappings, convertScimConfigMappingsSchemaToStruct(mappingMap))
 		}
 	}
@@ -1151,6 +1429,33 @@ func convertSCIMConfigSchemaToStruct(d *schema.ResourceData) *cloudflare.AccessA
 	return scimConfig
 }
 
+// accessApplicationSCIMMappingsWithEnabled returns the hashes of the SCIM
+// mappings that set `enabled` in the configuration. The attribute is computed,
+// so the other mappings must leave it to the API default rather than send
+// false.
+func accessApplicationSCIMMappingsWithEnabled(d *schema.ResourceData) map[int]bool {
+	configured := make(map[int]bool)
+	mappings := getRawValue("scim_config.0.mappings", d.GetRawConfig())
+	if mappings.IsNull() || !mappings.IsKnown() || !mappings.CanIterateElements() {
+		return configured
+	}
+
+	for _, mapping := range mappings.AsValueSlice() {
+		if mapping.IsNull() || !mapping.IsKnown() || mapping.GetAttr("enabled").IsNull() {
+			continue
+		}
+		key := make(map[string]interface{})
+		for _, attr := range []string{"schema", "filter"} {
+			if v := mapping.GetAttr(attr); v.IsKnown() && !v.IsNull() {
+				key[attr] = v.AsString()
+			}
+		}
+		configured[hashAccessApplicationSCIMMapping(key)] = true
+	}
+
+	return configured
+}
+
 func convertScimConfigMappingsSchemaToStruct(mappingData map[string]interface{}) *cloudflare.AccessApplicationScimMapping {
 	mapping := new(cloudflare.AccessApplicationScimMapping)
 
@@ -1207,9 +1512,22 @@ func convertScimConfigAuthenticationSchemaToStruct(d *schema.ResourceData) *clou
 	auth.Value = multi
 
 	keyFmt := "scim_config.0.authentication.%d"
-	i := 0
-	for _, ok := d.GetOk(fmt.Sprintf(keyFmt, i)); ok; _, ok = d.GetOk(fmt.Sprintf(keyFmt, i)) {
+	var keys []string
+	for i := 0; ; i++ {
 		key := fmt.Sprintf(keyFmt, i)
+		if _, ok := d.GetOk(key); !ok {
+			break
+		}
+		keys = append(keys, key)
+	}
+
+	// The API does not preserve the order of multiple authentication entries,
+	// send them ordered by scheme to match how they are read back.
+	sort.SliceStable(keys, func(i, j int) bool {
+		return d.Get(keys[i]+".scheme").(string) < d.Get(keys[j]+".scheme").(string)
+	})
+
+	for _, key := range keys {
 		scheme := cloudflare.AccessApplicationScimAuthenticationScheme(d.Get(key + ".scheme").(string))
 		switch scheme {
 		case cloudflare.AccessApplicationScimAuthenticationSchemeHttpBasic:
@@ -1243,8 +1561,6 @@ func convertScimConfigAuthenticationSchemaToStruct(d *schema.ResourceData) *clou
 			base.Scheme = scheme
 			*multi = append(*multi, &cloudflare.AccessApplicationScimAuthenticationSingleJSON{Value: base})
 		}
-
-		i++
 	}
 
 	return auth
@@ -1313,7 +1629,13 @@ func convertSAMLAttributeStructToSchema(attr cloudflare.SAMLAttributeConfig) map
 		m["friendly_name"] = attr.FriendlyName
 	}
 	if attr.Source.Name != "" {
-		m["source"] = []interface{}{map[string]interface{}{"name": attr.Source.Name, "name_by_idp": attr.Source.NameByIDP}}
+		source := map[string]interface{}{"name": attr.Source.Name}
+		// The API returns null when no per-IdP attribute names are
+		// configured, omit it rather than storing an empty map.
+		if len(attr.Source.NameByIDP) != 0 {
+			source["name_by_idp"] = attr.Source.NameByIDP
+		}
+		m["source"] = []interface{}{source}
 	}
 	return m
 }
@@ -1330,7 +1652,13 @@ func convertOIDCClaimStructToSchema(attr cloudflare.OIDCClaimConfig) map[string]
 		m["required"] = true
 	}
 	if attr.Source.Name != "" {
-		m["source"] = []interface{}{map[string]interface{}{"name": attr.Source.Name, "name_by_idp": attr.Source.NameByIDP}}
+		source := map[string]interface{}{"name": attr.Source.Name}
+		// The API returns `null` when no per identity provider names are
+		// configured, omit it rather than storing an empty map.
+		if len(attr.Source.NameByIDP) != 0 {
+			source["name_by_idp"] = attr.Source.NameByIDP
+		}
+		m["source"] = []interface{}{source}
 	}
 
 	return m
@@ -1352,7 +1680,7 @@ func convertSaasStructToSchema(d *schema.ResourceData, app *cloudflare.SaasAppli
 	if app == nil {
 		return []interface{}{}
 	}
-	if app.AuthType == "oidc" {
+	if app.AuthType == saasAuthTypeOIDC {
 		m := map[string]interface{}{
 			"auth_type":                        app.AuthType,
 			"client_id":                        app.ClientID,
@@ -1377,20 +1705,35 @@ func convertSaasStructToSchema(d *schema.ResourceData, app *cloudflare.SaasAppli
 			}
 		}
 		if len(customClaims) != 0 {
-			m["custom_claim"] = customClaims
+			priorClaims, _ := d.Get("saas_app.0.custom_claim").([]interface{})
+			m["custom_claim"] = sortByPriorOrder(customClaims, priorClaims, "name")
 		}
 
 		if app.HybridAndImplicitOptions != nil {
 			m["hybrid_and_implicit_options"] = convertHybridAndImplicitOptionsStructToSchema(app.HybridAndImplicitOptions)
 		}
 
+		// client id is generated by Access, keep the known value if a response
+		// omits it so the attribute is never emptied on refresh.
+		if app.ClientID == "" {
+			m["client_id"] = d.Get("saas_app.0.client_id").(string)
+		}
+
 		// client secret is only returned on create, if it is present in the state, preserve it
 		if client_secret, ok := d.GetOk("saas_app.0.client_secret"); ok {
 			m["client_secret"] = client_secret.(string)
 		}
 		return []interface{}{m}
 	} else {
+		// Applications created without an explicit `auth_type` are SAML, set it
+		// so that imported resources don't diff on the first plan.
+		authType := app.AuthType
+		if authType == "" {
+			authType = saasAuthTypeSAML
+		}
+
 		m := map[string]interface{}{
+			"auth_type":                        authType,
 			"sp_entity_id":                     app.SPEntityID,
 			"consumer_service_url":             app.ConsumerServiceUrl,
 			"name_id_format":                   app.NameIDFormat,
@@ -1409,7 +1752,8 @@ func convertSaasStructToSchema(d *schema.ResourceData, app *cloudflare.SaasAppli
 			}
 		}
 		if len(customAttributes) != 0 {
-			m["custom_attribute"] = customAttributes
+			priorAttributes, _ := d.Get("saas_app.0.custom_attribute").([]interface{})
+			m["custom_attribute"] = sortByPriorOrder(customAttributes, priorAttributes, "name", "name_format")
 		}
 
 		return []interface{}{m}
@@ -1423,8 +1767,7 @@ func convertTargetContextsToSchema(targetContexts *[]cloudflare.AccessInfrastruc
 	var targetContextsSchema []interface{}
 
 	for _, targetContext := range *targetContexts {
-		//targetAttributesList := []map[string][]string{}
-		var attributesReturned []map[string]interface{}
+		var attributesReturned []interface{}
 
 		for key, values := range targetContext.TargetAttributes {
 			attributeMap := map[string]interface{}{
@@ -1475,6 +1818,10 @@ func convertScimConfigAuthenticationStructToSchema(scimAuth *cloudflare.AccessAp
 			vals = append(vals, convertScimConfigSingleAuthentiationToSchema(&cloudflare.AccessApplicationScimAuthenticationJson{Value: authn.Value}))
 		}
 
+		sort.SliceStable(vals, func(i, j int) bool {
+			return fmt.Sprint(vals[i].(map[string]interface{})["scheme"]) < fmt.Sprint(vals[j].(map[string]interface{})["scheme"])
+		})
+
 		return vals
 	case *cloudflare.AccessApplicationScimAuthenticationHttpBasic:
 		auth["scheme"] = t.Scheme
@@ -15,6 +15,17 @@ var TeamsLocationNetworkSchema = &schema.Resource{
 	},
 }
 
+var TeamsLocationIPv6NetworkSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"network": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateIPv6CIDR,
+			Description:  "CIDR notation representation of the network IP.",
+		},
+	},
+}
+
 func resourceCloudflareTeamsLocationSchema() map[string]*schema.Schema {
 
 	return map[string]*schema.Schema{
@@ -35,6 +46,12 @@ func resourceCloudflareTeamsLocationSchema() map[string]*schema.Schema {
 			ConfigMode:  schema.SchemaConfigModeAttr,
 			Elem:        TeamsLocationNetworkSchema,
 		},
+		"network_ids": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The IDs the API assigned to the `networks` of the location, keyed by network CIDR.",
+		},
 		"client_default": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -57,7 +74,8 @@ func resourceCloudflareTeamsLocationSchema() map[string]*schema.Schema {
 		},
 		"anonymized_logs_enabled": {
 			Type:        schema.TypeBool,
-			Computed:    true,
+			Optional:    true,
+			Default:     false,
 			Description: "Indicator that anonymized logs are enabled.",
 		},
 		"ipv4_destination": {
@@ -96,7 +114,8 @@ var TeamsLocationEndpointSchema = &schema.Resource{
 	Schema: map[string]*schema.Schema{
 		"ipv4": {
 			Type:     schema.TypeList,
-			Optional: true,
+			Required: true,
+			MinItems: 1,
 			MaxItems: 1,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
@@ -113,7 +132,8 @@ var TeamsLocationEndpointSchema = &schema.Resource{
 		},
 		"ipv6": {
 			Type:     schema.TypeList,
-			Optional: true,
+			Required: true,
+			MinItems: 1,
 			MaxItems: 1,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
@@ -130,14 +150,15 @@ var TeamsLocationEndpointSchema = &schema.Resource{
 						ConfigMode: schema.SchemaConfigModeAttr,
 						MinItems:   1,
 						Optional:   true,
-						Elem:       TeamsLocationNetworkSchema,
+						Elem:       TeamsLocationIPv6NetworkSchema,
 					},
 				},
 			},
 		},
 		"doh": {
 			Type:     schema.TypeList,
-			Optional: true,
+			Required: true,
+			MinItems: 1,
 			MaxItems: 1,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
@@ -165,7 +186,8 @@ var TeamsLocationEndpointSchema = &schema.Resource{
 		},
 		"dot": {
 			Type:     schema.TypeList,
-			Optional: true,
+			Required: true,
+			MinItems: 1,
 			MaxItems: 1,
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
@@ -61,6 +61,55 @@ func HashByMapKey(key string) func(v interface{}) int {
 	}
 }
 
+// sortByPriorOrder orders a list of maps so that entries whose values for keys
+// are found in prior keep the position they had there. Entries that are not in
+// prior are placed after them, keeping their relative order. This prevents
+// diffs on lists the API returns in a different order than configured.
+func sortByPriorOrder(items, prior []interface{}, keys ...string) []interface{} {
+	identity := func(item interface{}) (string, bool) {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+
+		// Keys missing from maps built from API responses match the zero
+		// value read back from the state.
+		values := make([]string, len(keys))
+		for i, key := range keys {
+			if v, ok := m[key]; ok && v != nil {
+				values[i] = fmt.Sprint(v)
+			}
+		}
+		return strings.Join(values, "\x00"), true
+	}
+
+	positions := make(map[string]int, len(prior))
+	for i, p := range prior {
+		if id, ok := identity(p); ok {
+			if _, exists := positions[id]; !exists {
+				positions[id] = i
+			}
+		}
+	}
+
+	position := func(item interface{}) int {
+		if id, ok := identity(item); ok {
+			if i, ok := positions[id]; ok {
+				return i
+			}
+		}
+		return len(prior)
+	}
+
+	sorted := make([]interface{}, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return position(sorted[i]) < position(sorted[j])
+	})
+
+	return sorted
+}
+
 // stringChecksum takes a string and returns the checksum of the string.
 func stringChecksum(s string) string {
 	h := md5.New()
@@ -188,7 +237,7 @@ func getRawValue(key string, value cty.Value) cty.Value {
 	parts := strings.SplitN(key, ".", 2)
 	index, indexErr := strconv.ParseInt(parts[0], 10, 32)
 
-	if value.IsNull() {
+	if value.IsNull() || !value.IsKnown() {
 		return value
 	} else if indexErr == nil && value.CanIterateElements() && value.LengthInt() > int(index) {
 		value = value.AsValueSlice()[index]
@@ -209,6 +258,28 @@ func getRawValue(key string, value cty.Value) cty.Value {
 	return value
 }
 
+// getRawConfigString returns the string configured at key in a raw
+// configuration. ok is false when the value is unset, unknown or not a string.
+func getRawConfigString(config cty.Value, key string) (value string, ok bool) {
+	raw := getRawValue(key, config)
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().Equals(cty.String) {
+		return "", false
+	}
+
+	return raw.AsString(), true
+}
+
+// getRawConfigBool returns the bool configured at key in a raw configuration.
+// ok is false when the value is unset, unknown or not a bool.
+func getRawConfigBool(config cty.Value, key string) (value bool, ok bool) {
+	raw := getRawValue(key, config)
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().Equals(cty.Bool) {
+		return false, false
+	}
+
+	return raw.True(), true
+}
+
 // renderAvailableDocumentationValuesStringSlice takes a slice of strings and
 // formats it for documentation output use.
 //
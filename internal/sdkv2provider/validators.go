@@ -76,3 +76,73 @@ func validateURL(v interface{}, k string) (s []string, errors []error) {
 	}
 	return
 }
+
+// validateHTTPURL ensures the provided string is an absolute URL using the
+// http or https scheme. Empty values are accepted for optional fields.
+func validateHTTPURL(v interface{}, k string) (s []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q: %w", k, err))
+		return
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errors = append(errors, fmt.Errorf("%q must be an absolute http or https URL, got: %q", k, value))
+	}
+	return
+}
+
+// validateHTTPURLPreferHTTPS ensures the provided string is an absolute http
+// or https URL, warning rather than failing when plain http is used so that
+// local development endpoints remain configurable.
+func validateHTTPURLPreferHTTPS(v interface{}, k string) (s []string, errors []error) {
+	s, errors = validateHTTPURL(v, k)
+	if len(errors) > 0 {
+		return
+	}
+
+	value := v.(string)
+	if u, _ := url.Parse(value); value != "" && u.Scheme != "https" {
+		s = append(s, fmt.Sprintf("%q should use the https scheme, got: %q", k, value))
+	}
+	return
+}
+
+// validateHTTPSURL ensures the provided string is an absolute URL using the
+// https scheme.
+func validateHTTPSURL(v interface{}, k string) (s []string, errors []error) {
+	value := v.(string)
+
+	u, err := url.Parse(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q: %w", k, err))
+		return
+	}
+
+	if u.Scheme != "https" || u.Host == "" {
+		errors = append(errors, fmt.Errorf("%q must be an absolute https URL, got: %q", k, value))
+	}
+	return
+}
+
+// validateIPv6CIDR ensures the provided string is a CIDR range of the IPv6
+// address family.
+func validateIPv6CIDR(v interface{}, k string) (s []string, errors []error) {
+	value := v.(string)
+
+	ip, _, err := net.ParseCIDR(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid IPv6 CIDR, got: %q", k, value))
+		return
+	}
+
+	if ip.To4() != nil {
+		errors = append(errors, fmt.Errorf("%q must be an IPv6 CIDR, got the IPv4 CIDR: %q", k, value))
+	}
+	return
+}
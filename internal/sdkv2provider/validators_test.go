@@ -71,3 +71,105 @@ func TestValidateRecordName(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateHTTPURL(t *testing.T) {
+	t.Parallel()
+
+	validURLs := []string{
+		"",
+		"https://www.cloudflare.com",
+		"http://example.com/denied?reason=policy",
+	}
+	for _, v := range validURLs {
+		if _, errs := validateHTTPURL(v, "custom_deny_url"); len(errs) > 0 {
+			t.Fatalf("%q should be a valid URL: %v", v, errs)
+		}
+	}
+
+	invalidURLs := []string{
+		"www.cloudflare.com",
+		"/denied",
+		"ftp://example.com",
+		"https://",
+	}
+	for _, v := range invalidURLs {
+		if _, errs := validateHTTPURL(v, "custom_deny_url"); len(errs) == 0 {
+			t.Fatalf("%q should be an invalid URL", v)
+		}
+	}
+}
+
+func TestValidateHTTPURLPreferHTTPS(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value    string
+		warnings int
+		errors   int
+	}{
+		{"", 0, 0},
+		{"https://saas-app.example/sso/saml/consume", 0, 0},
+		{"http://localhost:8080/saml/consume", 1, 0},
+		{"ftp://saas-app.example/consume", 0, 1},
+		{"saas-app.example/consume", 0, 1},
+	}
+
+	for _, c := range cases {
+		warnings, errs := validateHTTPURLPreferHTTPS(c.value, "consumer_service_url")
+		if len(warnings) != c.warnings || len(errs) != c.errors {
+			t.Fatalf("%q: expected %d warnings and %d errors, got %v and %v", c.value, c.warnings, c.errors, warnings, errs)
+		}
+	}
+}
+
+func TestValidateHTTPSURL(t *testing.T) {
+	t.Parallel()
+
+	validURLs := []string{
+		"https://scim.example.com",
+		"https://example.com/scim/v2",
+	}
+	for _, v := range validURLs {
+		if _, errs := validateHTTPSURL(v, "remote_uri"); len(errs) > 0 {
+			t.Fatalf("%q should be a valid URL: %v", v, errs)
+		}
+	}
+
+	invalidURLs := []string{
+		"",
+		"http://scim.example.com",
+		"scim.example.com",
+		"https://",
+	}
+	for _, v := range invalidURLs {
+		if _, errs := validateHTTPSURL(v, "remote_uri"); len(errs) == 0 {
+			t.Fatalf("%q should be an invalid URL", v)
+		}
+	}
+}
+
+func TestValidateIPv6CIDR(t *testing.T) {
+	t.Parallel()
+
+	validCIDRs := []string{
+		"2001:db8::/32",
+		"2606:4700:4700::/48",
+	}
+	for _, v := range validCIDRs {
+		if _, errs := validateIPv6CIDR(v, "network"); len(errs) > 0 {
+			t.Fatalf("%q should be a valid IPv6 CIDR: %v", v, errs)
+		}
+	}
+
+	invalidCIDRs := []string{
+		"",
+		"192.0.2.0/24",
+		"2001:db8::",
+		"not-a-cidr",
+	}
+	for _, v := range invalidCIDRs {
+		if _, errs := validateIPv6CIDR(v, "network"); len(errs) == 0 {
+			t.Fatalf("%q should be an invalid IPv6 CIDR", v)
+		}
+	}
+}